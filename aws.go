@@ -3,12 +3,23 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect"
+	dxtypes "github.com/aws/aws-sdk-go-v2/service/directconnect/types"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53resolver"
+	r53rtypes "github.com/aws/aws-sdk-go-v2/service/route53resolver/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -20,6 +31,64 @@ import (
 
 const nametag = "Name"
 
+// partitions maps region prefixes onto the AWS partition they belong to.
+// Longer prefixes must be listed before any prefix they start with.
+var partitions = []struct {
+	prefix    string
+	partition string
+}{
+	{"cn-", "aws-cn"},
+	{"us-gov-", "aws-us-gov"},
+	{"us-isob-", "aws-iso-b"},
+	{"us-isof-", "aws-iso-f"},
+	{"us-iso-", "aws-iso"},
+	{"eu-isoe-", "aws-iso-e"},
+}
+
+// awsPartition returns the AWS partition a region belongs to
+func awsPartition(region string) string {
+	for _, p := range partitions {
+		if strings.HasPrefix(region, p.prefix) {
+			return p.partition
+		}
+	}
+	return "aws"
+}
+
+var (
+	// regionPattern matches region names such as `eu-west-1`, `cn-north-1`
+	// or `us-isob-east-1`
+	regionPattern *regexp.Regexp = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
+	// accountPattern matches 12 digit AWS account IDs
+	accountPattern *regexp.Regexp = regexp.MustCompile(`^[0-9]{12}$`)
+)
+
+// awsArn builds an ARN in the partition of the given region. The region and
+// account are validated first as a malformed region would otherwise silently
+// fall back to the `aws` partition
+func awsArn(service, region, account, resource string) (string, error) {
+	if region == "" || account == "" || resource == "" {
+		return "", errors.Errorf("cannot build %s ARN from region %q, account %q and resource %q", service, region, account, resource)
+	}
+
+	if !regionPattern.MatchString(region) {
+		return "", errors.Errorf("cannot build %s ARN for invalid region %q", service, region)
+	}
+
+	if !accountPattern.MatchString(account) {
+		return "", errors.Errorf("cannot build %s ARN for invalid account %q", service, account)
+	}
+
+	return arn.ARN{
+		Partition: awsPartition(region),
+		Service:   service,
+		Region:    region,
+		AccountID: account,
+		Resource:  resource,
+	}.String(), nil
+}
+
 // EC2API Describes the functions required to access data on the AWS EC2 api
 type AwsEc2Api interface {
 	DescribeVpcs(ctx context.Context,
@@ -49,47 +118,387 @@ type AwsEc2Api interface {
 	DescribeVpcPeeringConnections(ctx context.Context,
 		params *ec2.DescribeVpcPeeringConnectionsInput,
 		optFns ...func(*ec2.Options)) (*ec2.DescribeVpcPeeringConnectionsOutput, error)
+	DescribeVpcEndpoints(ctx context.Context,
+		params *ec2.DescribeVpcEndpointsInput,
+		optFns ...func(*ec2.Options)) (*ec2.DescribeVpcEndpointsOutput, error)
+	DescribeNetworkAcls(ctx context.Context,
+		params *ec2.DescribeNetworkAclsInput,
+		optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkAclsOutput, error)
+	SearchTransitGatewayRoutes(ctx context.Context,
+		params *ec2.SearchTransitGatewayRoutesInput,
+		optFns ...func(*ec2.Options)) (*ec2.SearchTransitGatewayRoutesOutput, error)
+	GetTransitGatewayRouteTableAssociations(ctx context.Context,
+		params *ec2.GetTransitGatewayRouteTableAssociationsInput,
+		optFns ...func(*ec2.Options)) (*ec2.GetTransitGatewayRouteTableAssociationsOutput, error)
+	GetTransitGatewayRouteTablePropagations(ctx context.Context,
+		params *ec2.GetTransitGatewayRouteTablePropagationsInput,
+		optFns ...func(*ec2.Options)) (*ec2.GetTransitGatewayRouteTablePropagationsOutput, error)
+	DescribeTransitGatewayPeeringAttachments(ctx context.Context,
+		params *ec2.DescribeTransitGatewayPeeringAttachmentsInput,
+		optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayPeeringAttachmentsOutput, error)
+	DescribeVpnGateways(ctx context.Context,
+		params *ec2.DescribeVpnGatewaysInput,
+		optFns ...func(*ec2.Options)) (*ec2.DescribeVpnGatewaysOutput, error)
+	DescribeVpnConnections(ctx context.Context,
+		params *ec2.DescribeVpnConnectionsInput,
+		optFns ...func(*ec2.Options)) (*ec2.DescribeVpnConnectionsOutput, error)
+	DescribeCustomerGateways(ctx context.Context,
+		params *ec2.DescribeCustomerGatewaysInput,
+		optFns ...func(*ec2.Options)) (*ec2.DescribeCustomerGatewaysOutput, error)
+	DescribeDhcpOptions(ctx context.Context,
+		params *ec2.DescribeDhcpOptionsInput,
+		optFns ...func(*ec2.Options)) (*ec2.DescribeDhcpOptionsOutput, error)
+	GetSubnetCidrReservations(ctx context.Context,
+		params *ec2.GetSubnetCidrReservationsInput,
+		optFns ...func(*ec2.Options)) (*ec2.GetSubnetCidrReservationsOutput, error)
+	DescribeAvailabilityZones(ctx context.Context,
+		params *ec2.DescribeAvailabilityZonesInput,
+		optFns ...func(*ec2.Options)) (*ec2.DescribeAvailabilityZonesOutput, error)
+}
+
+// AwsDirectConnectApi Describes the functions required to access data on the
+// AWS Direct Connect api
+type AwsDirectConnectApi interface {
+	DescribeDirectConnectGatewayAssociations(ctx context.Context,
+		params *directconnect.DescribeDirectConnectGatewayAssociationsInput,
+		optFns ...func(*directconnect.Options)) (*directconnect.DescribeDirectConnectGatewayAssociationsOutput, error)
+}
+
+// AwsRoute53Api Describes the functions required to access data on the AWS
+// Route 53 api
+type AwsRoute53Api interface {
+	ListHostedZonesByVPC(ctx context.Context,
+		params *route53.ListHostedZonesByVPCInput,
+		optFns ...func(*route53.Options)) (*route53.ListHostedZonesByVPCOutput, error)
+}
+
+// AwsRoute53ResolverApi Describes the functions required to access data on the
+// AWS Route 53 Resolver api
+type AwsRoute53ResolverApi interface {
+	ListResolverRuleAssociations(ctx context.Context,
+		params *route53resolver.ListResolverRuleAssociationsInput,
+		optFns ...func(*route53resolver.Options)) (*route53resolver.ListResolverRuleAssociationsOutput, error)
+	GetResolverRule(ctx context.Context,
+		params *route53resolver.GetResolverRuleInput,
+		optFns ...func(*route53resolver.Options)) (*route53resolver.GetResolverRuleOutput, error)
 }
 
 type AwsStsApi interface {
 	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
 }
 
-// Get the EC2 Launch template versions for a given launch template
+// Where the underlying call is paginated, the helpers below follow NextToken
+// through every page and return a single output holding the combined results.
+// Only the result slice is populated on the returned output.
+
 func GetVpc(c context.Context, api AwsEc2Api, input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
-	return api.DescribeVpcs(c, input)
+	var (
+		output    *ec2.DescribeVpcsOutput    = &ec2.DescribeVpcsOutput{}
+		paginator *ec2.DescribeVpcsPaginator = ec2.NewDescribeVpcsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.Vpcs = append(output.Vpcs, page.Vpcs...)
+	}
+	return output, nil
 }
 
 func GetSubnets(c context.Context, api AwsEc2Api, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
-	return api.DescribeSubnets(c, input)
+	var (
+		output    *ec2.DescribeSubnetsOutput    = &ec2.DescribeSubnetsOutput{}
+		paginator *ec2.DescribeSubnetsPaginator = ec2.NewDescribeSubnetsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.Subnets = append(output.Subnets, page.Subnets...)
+	}
+	return output, nil
 }
 
 func GetSecurityGroups(c context.Context, api AwsEc2Api, input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
-	return api.DescribeSecurityGroups(c, input)
+	var (
+		output    *ec2.DescribeSecurityGroupsOutput    = &ec2.DescribeSecurityGroupsOutput{}
+		paginator *ec2.DescribeSecurityGroupsPaginator = ec2.NewDescribeSecurityGroupsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.SecurityGroups = append(output.SecurityGroups, page.SecurityGroups...)
+	}
+	return output, nil
 }
 
 func GetRouteTables(c context.Context, api AwsEc2Api, input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
-	return api.DescribeRouteTables(c, input)
+	var (
+		output    *ec2.DescribeRouteTablesOutput    = &ec2.DescribeRouteTablesOutput{}
+		paginator *ec2.DescribeRouteTablesPaginator = ec2.NewDescribeRouteTablesPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.RouteTables = append(output.RouteTables, page.RouteTables...)
+	}
+	return output, nil
 }
 
 func GetNatGateways(c context.Context, api AwsEc2Api, input *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
-	return api.DescribeNatGateways(c, input)
+	var (
+		output    *ec2.DescribeNatGatewaysOutput    = &ec2.DescribeNatGatewaysOutput{}
+		paginator *ec2.DescribeNatGatewaysPaginator = ec2.NewDescribeNatGatewaysPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.NatGateways = append(output.NatGateways, page.NatGateways...)
+	}
+	return output, nil
 }
 
 func GetTransitGateways(c context.Context, api AwsEc2Api, input *ec2.DescribeTransitGatewaysInput) (*ec2.DescribeTransitGatewaysOutput, error) {
-	return api.DescribeTransitGateways(c, input)
+	var (
+		output    *ec2.DescribeTransitGatewaysOutput    = &ec2.DescribeTransitGatewaysOutput{}
+		paginator *ec2.DescribeTransitGatewaysPaginator = ec2.NewDescribeTransitGatewaysPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.TransitGateways = append(output.TransitGateways, page.TransitGateways...)
+	}
+	return output, nil
 }
 
 func GetTransitGatewayAttachments(c context.Context, api AwsEc2Api, input *ec2.DescribeTransitGatewayAttachmentsInput) (*ec2.DescribeTransitGatewayAttachmentsOutput, error) {
-	return api.DescribeTransitGatewayAttachments(c, input)
+	var (
+		output    *ec2.DescribeTransitGatewayAttachmentsOutput    = &ec2.DescribeTransitGatewayAttachmentsOutput{}
+		paginator *ec2.DescribeTransitGatewayAttachmentsPaginator = ec2.NewDescribeTransitGatewayAttachmentsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.TransitGatewayAttachments = append(output.TransitGatewayAttachments, page.TransitGatewayAttachments...)
+	}
+	return output, nil
 }
 
 func GetTransitGatewayRouteTables(c context.Context, api AwsEc2Api, input *ec2.DescribeTransitGatewayRouteTablesInput) (*ec2.DescribeTransitGatewayRouteTablesOutput, error) {
-	return api.DescribeTransitGatewayRouteTables(c, input)
+	var (
+		output    *ec2.DescribeTransitGatewayRouteTablesOutput    = &ec2.DescribeTransitGatewayRouteTablesOutput{}
+		paginator *ec2.DescribeTransitGatewayRouteTablesPaginator = ec2.NewDescribeTransitGatewayRouteTablesPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.TransitGatewayRouteTables = append(output.TransitGatewayRouteTables, page.TransitGatewayRouteTables...)
+	}
+	return output, nil
 }
 
 func GetVpcPeeringConnections(c context.Context, api AwsEc2Api, input *ec2.DescribeVpcPeeringConnectionsInput) (*ec2.DescribeVpcPeeringConnectionsOutput, error) {
-	return api.DescribeVpcPeeringConnections(c, input)
+	var (
+		output    *ec2.DescribeVpcPeeringConnectionsOutput    = &ec2.DescribeVpcPeeringConnectionsOutput{}
+		paginator *ec2.DescribeVpcPeeringConnectionsPaginator = ec2.NewDescribeVpcPeeringConnectionsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.VpcPeeringConnections = append(output.VpcPeeringConnections, page.VpcPeeringConnections...)
+	}
+	return output, nil
+}
+
+func GetVpcEndpoints(c context.Context, api AwsEc2Api, input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+	var (
+		output    *ec2.DescribeVpcEndpointsOutput    = &ec2.DescribeVpcEndpointsOutput{}
+		paginator *ec2.DescribeVpcEndpointsPaginator = ec2.NewDescribeVpcEndpointsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.VpcEndpoints = append(output.VpcEndpoints, page.VpcEndpoints...)
+	}
+	return output, nil
+}
+
+func GetNetworkAcls(c context.Context, api AwsEc2Api, input *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error) {
+	var (
+		output    *ec2.DescribeNetworkAclsOutput    = &ec2.DescribeNetworkAclsOutput{}
+		paginator *ec2.DescribeNetworkAclsPaginator = ec2.NewDescribeNetworkAclsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.NetworkAcls = append(output.NetworkAcls, page.NetworkAcls...)
+	}
+	return output, nil
+}
+
+func SearchTransitGatewayRoutes(c context.Context, api AwsEc2Api, input *ec2.SearchTransitGatewayRoutesInput) (*ec2.SearchTransitGatewayRoutesOutput, error) {
+	return api.SearchTransitGatewayRoutes(c, input)
+}
+
+func GetTransitGatewayRouteTableAssociations(c context.Context, api AwsEc2Api, input *ec2.GetTransitGatewayRouteTableAssociationsInput) (*ec2.GetTransitGatewayRouteTableAssociationsOutput, error) {
+	var (
+		output    *ec2.GetTransitGatewayRouteTableAssociationsOutput    = &ec2.GetTransitGatewayRouteTableAssociationsOutput{}
+		paginator *ec2.GetTransitGatewayRouteTableAssociationsPaginator = ec2.NewGetTransitGatewayRouteTableAssociationsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.Associations = append(output.Associations, page.Associations...)
+	}
+	return output, nil
+}
+
+func GetTransitGatewayRouteTablePropagations(c context.Context, api AwsEc2Api, input *ec2.GetTransitGatewayRouteTablePropagationsInput) (*ec2.GetTransitGatewayRouteTablePropagationsOutput, error) {
+	var (
+		output    *ec2.GetTransitGatewayRouteTablePropagationsOutput    = &ec2.GetTransitGatewayRouteTablePropagationsOutput{}
+		paginator *ec2.GetTransitGatewayRouteTablePropagationsPaginator = ec2.NewGetTransitGatewayRouteTablePropagationsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.TransitGatewayRouteTablePropagations = append(output.TransitGatewayRouteTablePropagations, page.TransitGatewayRouteTablePropagations...)
+	}
+	return output, nil
+}
+
+func GetTransitGatewayPeeringAttachments(c context.Context, api AwsEc2Api, input *ec2.DescribeTransitGatewayPeeringAttachmentsInput) (*ec2.DescribeTransitGatewayPeeringAttachmentsOutput, error) {
+	var (
+		output    *ec2.DescribeTransitGatewayPeeringAttachmentsOutput    = &ec2.DescribeTransitGatewayPeeringAttachmentsOutput{}
+		paginator *ec2.DescribeTransitGatewayPeeringAttachmentsPaginator = ec2.NewDescribeTransitGatewayPeeringAttachmentsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.TransitGatewayPeeringAttachments = append(output.TransitGatewayPeeringAttachments, page.TransitGatewayPeeringAttachments...)
+	}
+	return output, nil
+}
+
+func GetVpnGateways(c context.Context, api AwsEc2Api, input *ec2.DescribeVpnGatewaysInput) (*ec2.DescribeVpnGatewaysOutput, error) {
+	return api.DescribeVpnGateways(c, input)
+}
+
+func GetVpnConnections(c context.Context, api AwsEc2Api, input *ec2.DescribeVpnConnectionsInput) (*ec2.DescribeVpnConnectionsOutput, error) {
+	return api.DescribeVpnConnections(c, input)
+}
+
+func GetCustomerGateways(c context.Context, api AwsEc2Api, input *ec2.DescribeCustomerGatewaysInput) (*ec2.DescribeCustomerGatewaysOutput, error) {
+	return api.DescribeCustomerGateways(c, input)
+}
+
+func GetDhcpOptions(c context.Context, api AwsEc2Api, input *ec2.DescribeDhcpOptionsInput) (*ec2.DescribeDhcpOptionsOutput, error) {
+	var (
+		output    *ec2.DescribeDhcpOptionsOutput    = &ec2.DescribeDhcpOptionsOutput{}
+		paginator *ec2.DescribeDhcpOptionsPaginator = ec2.NewDescribeDhcpOptionsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.DhcpOptions = append(output.DhcpOptions, page.DhcpOptions...)
+	}
+	return output, nil
+}
+
+func GetSubnetCidrReservations(c context.Context, api AwsEc2Api, input *ec2.GetSubnetCidrReservationsInput) (*ec2.GetSubnetCidrReservationsOutput, error) {
+	var output *ec2.GetSubnetCidrReservationsOutput = &ec2.GetSubnetCidrReservationsOutput{}
+	for {
+		page, err := api.GetSubnetCidrReservations(c, input)
+		if err != nil {
+			return nil, err
+		}
+		output.SubnetIpv4CidrReservations = append(output.SubnetIpv4CidrReservations, page.SubnetIpv4CidrReservations...)
+		output.SubnetIpv6CidrReservations = append(output.SubnetIpv6CidrReservations, page.SubnetIpv6CidrReservations...)
+		if aws.ToString(page.NextToken) == "" {
+			return output, nil
+		}
+		input.NextToken = page.NextToken
+	}
+}
+
+func GetAvailabilityZones(c context.Context, api AwsEc2Api, input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	return api.DescribeAvailabilityZones(c, input)
+}
+
+func GetDirectConnectGatewayAssociations(c context.Context, api AwsDirectConnectApi, input *directconnect.DescribeDirectConnectGatewayAssociationsInput) (*directconnect.DescribeDirectConnectGatewayAssociationsOutput, error) {
+	var output *directconnect.DescribeDirectConnectGatewayAssociationsOutput = &directconnect.DescribeDirectConnectGatewayAssociationsOutput{}
+	for {
+		page, err := api.DescribeDirectConnectGatewayAssociations(c, input)
+		if err != nil {
+			return nil, err
+		}
+		output.DirectConnectGatewayAssociations = append(output.DirectConnectGatewayAssociations, page.DirectConnectGatewayAssociations...)
+		if aws.ToString(page.NextToken) == "" {
+			return output, nil
+		}
+		input.NextToken = page.NextToken
+	}
+}
+
+func GetHostedZonesByVpc(c context.Context, api AwsRoute53Api, input *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error) {
+	var output *route53.ListHostedZonesByVPCOutput = &route53.ListHostedZonesByVPCOutput{}
+	for {
+		page, err := api.ListHostedZonesByVPC(c, input)
+		if err != nil {
+			return nil, err
+		}
+		output.HostedZoneSummaries = append(output.HostedZoneSummaries, page.HostedZoneSummaries...)
+		if aws.ToString(page.NextToken) == "" {
+			return output, nil
+		}
+		input.NextToken = page.NextToken
+	}
+}
+
+func GetResolverRuleAssociations(c context.Context, api AwsRoute53ResolverApi, input *route53resolver.ListResolverRuleAssociationsInput) (*route53resolver.ListResolverRuleAssociationsOutput, error) {
+	var (
+		output    *route53resolver.ListResolverRuleAssociationsOutput    = &route53resolver.ListResolverRuleAssociationsOutput{}
+		paginator *route53resolver.ListResolverRuleAssociationsPaginator = route53resolver.NewListResolverRuleAssociationsPaginator(api, input)
+	)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(c)
+		if err != nil {
+			return nil, err
+		}
+		output.ResolverRuleAssociations = append(output.ResolverRuleAssociations, page.ResolverRuleAssociations...)
+	}
+	return output, nil
+}
+
+func GetResolverRule(c context.Context, api AwsRoute53ResolverApi, input *route53resolver.GetResolverRuleInput) (*route53resolver.GetResolverRuleOutput, error) {
+	return api.GetResolverRule(c, input)
 }
 
 func GetCallerIdentity(c context.Context, api AwsStsApi, input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
@@ -106,6 +515,33 @@ var (
 		return ec2.NewFromConfig(cfg)
 	}
 
+	getDirectConnectClient = func(cfg aws.Config, ep string) AwsDirectConnectApi {
+		if ep != "" {
+			return directconnect.NewFromConfig(cfg, func(o *directconnect.Options) {
+				o.BaseEndpoint = &ep
+			})
+		}
+		return directconnect.NewFromConfig(cfg)
+	}
+
+	getRoute53Client = func(cfg aws.Config, ep string) AwsRoute53Api {
+		if ep != "" {
+			return route53.NewFromConfig(cfg, func(o *route53.Options) {
+				o.BaseEndpoint = &ep
+			})
+		}
+		return route53.NewFromConfig(cfg)
+	}
+
+	getRoute53ResolverClient = func(cfg aws.Config, ep string) AwsRoute53ResolverApi {
+		if ep != "" {
+			return route53resolver.NewFromConfig(cfg, func(o *route53resolver.Options) {
+				o.BaseEndpoint = &ep
+			})
+		}
+		return route53resolver.NewFromConfig(cfg)
+	}
+
 	getStsClient = func(cfg aws.Config, ep string) AwsStsApi {
 		if ep != "" {
 			return sts.NewFromConfig(cfg, func(o *sts.Options) {
@@ -163,12 +599,7 @@ func (f *Function) ReadVpc(input *inp.RemoteVpc) (vpc xfnd.AwsVpc, err error) {
 		cfg      aws.Config
 		services map[string]string
 		vpcInput *ec2.DescribeVpcsInput = &ec2.DescribeVpcsInput{
-			Filters: []ec2types.Filter{
-				{
-					Name:   aws.String("tag:Name"),
-					Values: []string{input.Name},
-				},
-			},
+			Filters: vpcFilters(input),
 		}
 		ec2client AwsEc2Api
 	)
@@ -188,14 +619,95 @@ func (f *Function) ReadVpc(input *inp.RemoteVpc) (vpc xfnd.AwsVpc, err error) {
 
 	f.log.Info("setting up ec2 client to region " + input.Region + " with provider config " + input.ProviderConfig + " and endpoint " + ep)
 	ec2client = getEc2Client(cfg, ep)
-	vpc, err = f.getVpc(ec2client, vpcInput, &input.GroupBy)
+	if vpc, err = f.getVpc(ec2client, vpcInput, input); err != nil {
+		return
+	}
+
+	// Direct Connect is only queried when requested. If the lookup fails the
+	// VPC is returned without gateway associations
+	if input.Discover != nil && input.Discover.DirectConnect {
+		var e error
+		if vpc.DirectConnectGatewayAssociations, e = f.getDirectConnectGatewayAssociations(getDirectConnectClient(cfg, services["directconnect"]), &vpc); e != nil {
+			f.log.Info("Error getting Direct Connect gateway associations - skipping", "error", e)
+		}
+	}
+
+	// DNS is only queried when requested. If a lookup fails the respective
+	// map is left empty
+	if input.Discover != nil && input.Discover.DNS {
+		vpc.DNS = &xfnd.VpcDns{}
+
+		var e error
+		if vpc.DNS.PrivateHostedZones, e = f.getPrivateHostedZones(getRoute53Client(cfg, services["route53"]), vpc.ID, input.Region); e != nil {
+			f.log.Info("Error getting private hosted zones - skipping", "error", e)
+		}
+
+		if vpc.DNS.ResolverRules, e = f.getResolverRules(getRoute53ResolverClient(cfg, services["route53resolver"]), vpc.ID); e != nil {
+			f.log.Info("Error getting Resolver rules - skipping", "error", e)
+		}
+	}
+	return
+}
+
+// vpcFilters builds the filters used to look up a VPC. The VPC ID, tags and
+// CIDR block are combined when given, otherwise the VPC is looked up by the
+// value of its Name tag.
+func vpcFilters(input *inp.RemoteVpc) (filters []ec2types.Filter) {
+	if input.ID != "" {
+		filters = append(filters, ec2types.Filter{
+			Name:   aws.String("vpc-id"),
+			Values: []string{input.ID},
+		})
+	}
+
+	filters = append(filters, tagFilters(input.Tags)...)
+
+	if input.CidrBlock != "" {
+		var name string = "cidr-block-association.cidr-block"
+		if strings.Contains(input.CidrBlock, ":") {
+			name = "ipv6-cidr-block-association.ipv6-cidr-block"
+		}
+
+		filters = append(filters, ec2types.Filter{
+			Name:   aws.String(name),
+			Values: []string{input.CidrBlock},
+		})
+	}
+
+	if len(filters) == 0 {
+		filters = append(filters, ec2types.Filter{
+			Name:   aws.String("tag:" + nametag),
+			Values: []string{input.Name},
+		})
+	}
+	return
+}
+
+// tagFilters converts a list of tag filters into EC2 filters. Tags without a
+// value match on the tag key only.
+func tagFilters(tags []inp.TagFilter) (filters []ec2types.Filter) {
+	for _, t := range tags {
+		if t.Value == "" {
+			filters = append(filters, ec2types.Filter{
+				Name:   aws.String("tag-key"),
+				Values: []string{t.Key},
+			})
+			continue
+		}
+
+		filters = append(filters, ec2types.Filter{
+			Name:   aws.String("tag:" + t.Key),
+			Values: []string{t.Value},
+		})
+	}
 	return
 }
 
-func (f *Function) getVpc(client AwsEc2Api, input *ec2.DescribeVpcsInput, groupTag *string) (v xfnd.AwsVpc, err error) {
+func (f *Function) getVpc(client AwsEc2Api, input *ec2.DescribeVpcsInput, remote *inp.RemoteVpc) (v xfnd.AwsVpc, err error) {
 	var (
-		vpcOutput   *ec2.DescribeVpcsOutput
-		subnetInput *ec2.DescribeSubnetsInput
+		vpcOutput *ec2.DescribeVpcsOutput
+		vpc       ec2types.Vpc
+		matches   []string
 	)
 	vpcOutput, err = GetVpc(context.Background(), client, input)
 	if err != nil {
@@ -207,40 +719,73 @@ func (f *Function) getVpc(client AwsEc2Api, input *ec2.DescribeVpcsInput, groupT
 		err = errors.New("VPC not found")
 		return
 	}
-	f.log.Info("Processing VPC", "vpc", *vpcOutput.Vpcs[0].VpcId)
 
-	subnetInput = &ec2.DescribeSubnetsInput{
-		Filters: []ec2types.Filter{
-			{
-				Name:   aws.String("vpc-id"),
-				Values: []string{*vpcOutput.Vpcs[0].VpcId},
-			},
-		},
+	vpc = vpcOutput.Vpcs[0]
+	if len(vpcOutput.Vpcs) > 1 {
+		for _, m := range vpcOutput.Vpcs {
+			matches = append(matches, *m.VpcId)
+		}
+
+		if vpc, err = selectVpc(vpcOutput.Vpcs, remote.Ambiguity); err != nil {
+			err = errors.Wrapf(err, "found %d VPCs matching %q %v", len(matches), remote.Name, matches)
+			return
+		}
+		f.log.Info("Selected VPC from multiple matches", "vpc", *vpc.VpcId, "matches", matches)
+	}
+	f.log.Info("Processing VPC", "vpc", *vpc.VpcId)
+
+	var availabilityZones []xfnd.AvailabilityZone
+	{
+		var e error
+		if availabilityZones, e = f.getAvailabilityZones(client); e != nil {
+			f.log.Info("Error getting availability zones - skipping", "error", e)
+		}
 	}
 
 	var subnets map[string]xfnd.AwsSubnet
 	var count int
 	{
-		count, subnets, err = f.getSubnets(client, subnetInput, groupTag)
+		count, subnets, err = f.getSubnets(client, *vpc.VpcId, remote)
 		if err != nil {
 			return
 		}
 	}
 
+	var (
+		networkAcls       map[string]xfnd.NetworkAcl
+		subnetNetworkAcls map[string]string
+	)
+	{
+		var e error
+		if networkAcls, subnetNetworkAcls, e = f.getNetworkAcls(client, *vpc.VpcId); e != nil {
+			f.log.Info("Error getting Network ACLs - skipping", "error", e)
+		}
+	}
+
 	var (
 		publicSubnets         []xfnd.StatusSubnets              = make([]xfnd.StatusSubnets, count)
 		privateSubnets        []xfnd.StatusSubnets              = make([]xfnd.StatusSubnets, count)
 		publicRouteTables     []xfnd.StatusRouteTables          = make([]xfnd.StatusRouteTables, count)
 		privateRouteTables    []xfnd.StatusRouteTables          = make([]xfnd.StatusRouteTables, count)
-		natGateways           map[string]string                 = make(map[string]string, count)
+		natGateways           map[string]xfnd.NatGateway        = make(map[string]xfnd.NatGateway, count)
 		transitGateways       map[string]xfnd.TransitGateway    = make(map[string]xfnd.TransitGateway, count)
 		vpcPeeringConnections map[string]xfnd.PeeringConnection = make(map[string]xfnd.PeeringConnection, count)
+		capacity              xfnd.SubnetCapacity
+		subnetSetCapacity     []xfnd.SubnetCapacity = make([]xfnd.SubnetCapacity, count)
 		igw                   string
+		eigw                  string
 	)
 	{
+		var zoneTypes map[string]string = make(map[string]string, len(availabilityZones))
+		for _, az := range availabilityZones {
+			zoneTypes[az.ID] = az.Type
+		}
+
 		for n, sn := range subnets {
+			sn.AvailabilityZoneType = zoneTypes[sn.AvailabilityZoneID]
+
 			var g int = sn.SubnetSet
-			if g <= count {
+			if g < count {
 				if publicSubnets[g] == nil {
 					publicSubnets[g] = make(map[string]xfnd.StatusSubnetDetails)
 				}
@@ -257,35 +802,59 @@ func (f *Function) getVpc(client AwsEc2Api, input *ec2.DescribeVpcsInput, groupT
 					privateRouteTables[g] = make(map[string]xfnd.StatusRouteTableDetails)
 				}
 
+				var details xfnd.StatusSubnetDetails = xfnd.StatusSubnetDetails{
+					ARN:                           sn.ARN,
+					ID:                            sn.ID,
+					AvailabilityZone:              sn.AvailabilityZone,
+					AvailabilityZoneID:            sn.AvailabilityZoneID,
+					AvailabilityZoneType:          sn.AvailabilityZoneType,
+					AvailableIpAddressCount:       sn.AvailableIpAddressCount,
+					Capacity:                      sn.Capacity,
+					CidrBlock:                     sn.CidrBlock,
+					CidrReservations:              sn.CidrReservations,
+					DefaultForAz:                  sn.DefaultForAz,
+					ImplicitRouteTableAssociation: sn.ImplicitRouteTableAssociation,
+					NetworkAclID:                  subnetNetworkAcls[sn.ID],
+					IsIpv6:                        sn.IsIpv6,
+					Ipv6CidrBlock:                 sn.Ipv6CidrBlock,
+					Ipv6Native:                    sn.Ipv6Native,
+				}
+
+				addCapacity(&subnetSetCapacity[g], sn.Capacity)
+
 				if sn.IsPublic {
-					publicSubnets[g][n] = xfnd.StatusSubnetDetails{
-						ARN: sn.ARN,
-						ID:  sn.ID,
-					}
+					publicSubnets[g][n] = details
 				} else {
-					privateSubnets[g][n] = xfnd.StatusSubnetDetails{
-						ARN: sn.ARN,
-						ID:  sn.ID,
-					}
+					privateSubnets[g][n] = details
 				}
 
 				for n, rt := range sn.RouteTables {
 					if rt.IsPublic {
 						publicRouteTables[g][n] = xfnd.StatusRouteTableDetails{
-							ID: rt.ID,
+							ID:                     rt.ID,
+							PropagatingVpnGateways: rt.PropagatingVpnGateways,
+							Routes:                 rt.Routes,
 						}
 					} else {
 						privateRouteTables[g][n] = xfnd.StatusRouteTableDetails{
-							ID: rt.ID,
+							ID:                     rt.ID,
+							PropagatingVpnGateways: rt.PropagatingVpnGateways,
+							Routes:                 rt.Routes,
 						}
 					}
 				}
 			}
 
+			addCapacity(&capacity, sn.Capacity)
+
 			if sn.InternetGateway != "" {
 				igw = sn.InternetGateway
 			}
 
+			if sn.EgressOnlyInternetGateway != "" {
+				eigw = sn.EgressOnlyInternetGateway
+			}
+
 			if sn.NatGateways != nil {
 				for nat, natgw := range sn.NatGateways {
 					f.log.Info("Processing NAT Gateway", "nat", nat, "natgw", natgw)
@@ -309,79 +878,388 @@ func (f *Function) getVpc(client AwsEc2Api, input *ec2.DescribeVpcsInput, groupT
 		}
 	}
 
-	var securitygroups map[string]string
+	var (
+		securitygroups       map[string]string
+		securitygroupDetails map[string]xfnd.SecurityGroup
+	)
 	{
-		securitygroups, err = f.getSecurityGroups(client, *vpcOutput.Vpcs[0].VpcId)
+		securitygroups, securitygroupDetails, err = f.getSecurityGroups(client, *vpc.VpcId, remote.SecurityGroups)
 		if err != nil {
 			return
 		}
 	}
 
+	var endpoints map[string][]xfnd.VpcEndpoint
+	{
+		var e error
+		if endpoints, e = f.getVpcEndpoints(client, *vpc.VpcId); e != nil {
+			f.log.Info("Error getting VPC Endpoints - skipping", "error", e)
+		}
+	}
+
+	var (
+		vpnGateway       *xfnd.VpnGateway
+		vpnConnections   map[string]xfnd.VpnConnection
+		customerGateways map[string]xfnd.CustomerGateway
+	)
+	{
+		var e error
+		if vpnGateway, vpnConnections, customerGateways, e = f.getVpnGateway(client, *vpc.VpcId); e != nil {
+			f.log.Info("Error getting VPN Gateways - skipping", "error", e)
+		}
+	}
+
+	var dhcpOptions *xfnd.DhcpOptions
+	{
+		var e error
+		if dhcpOptions, e = f.getDhcpOptions(client, aws.ToString(vpc.DhcpOptionsId)); e != nil {
+			f.log.Info("Error getting DHCP options - skipping", "error", e)
+		}
+	}
+
 	var additionalCidrBlocks []string = make([]string, 0)
 	{
-		for _, cidr := range vpcOutput.Vpcs[0].CidrBlockAssociationSet {
-			if *cidr.CidrBlock != *vpcOutput.Vpcs[0].CidrBlock {
+		for _, cidr := range vpc.CidrBlockAssociationSet {
+			if *cidr.CidrBlock != *vpc.CidrBlock {
 				additionalCidrBlocks = append(additionalCidrBlocks, *cidr.CidrBlock)
 			}
 		}
 	}
 
+	var ipv6CidrBlocks []string = make([]string, 0)
+	{
+		for _, cidr := range vpc.Ipv6CidrBlockAssociationSet {
+			if cidr.Ipv6CidrBlockState != nil && cidr.Ipv6CidrBlockState.State != ec2types.VpcCidrBlockStateCodeAssociated {
+				continue
+			}
+			ipv6CidrBlocks = append(ipv6CidrBlocks, aws.ToString(cidr.Ipv6CidrBlock))
+		}
+	}
+
+	publicSubnets, privateSubnets = resize(publicSubnets), resize(privateSubnets)
+	subnetSetCapacity = subnetSetCapacity[:max(len(publicSubnets), len(privateSubnets))]
+
 	v = xfnd.AwsVpc{
-		AdditionalCidrBlocks:  additionalCidrBlocks,
-		CidrBlock:             *vpcOutput.Vpcs[0].CidrBlock,
-		ID:                    *vpcOutput.Vpcs[0].VpcId,
-		InternetGateway:       igw,
-		NatGateways:           natGateways,
-		Owner:                 *vpcOutput.Vpcs[0].OwnerId,
-		PublicSubnets:         resize(publicSubnets),
-		PrivateSubnets:        resize(privateSubnets),
-		PublicRouteTables:     resize(publicRouteTables),
-		PrivateRouteTables:    resize(privateRouteTables),
-		SecurityGroups:        securitygroups,
-		TransitGateways:       transitGateways,
-		VpcPeeringConnections: vpcPeeringConnections,
+		AdditionalCidrBlocks:      additionalCidrBlocks,
+		AvailabilityZones:         availabilityZones,
+		Capacity:                  &capacity,
+		CidrBlock:                 *vpc.CidrBlock,
+		CustomerGateways:          customerGateways,
+		DhcpOptions:               dhcpOptions,
+		EgressOnlyInternetGateway: eigw,
+		ID:                        *vpc.VpcId,
+		InternetGateway:           igw,
+		Ipv6CidrBlocks:            ipv6CidrBlocks,
+		Matches:                   matches,
+		NatGateways:               natGateways,
+		NetworkAcls:               networkAcls,
+		Owner:                     *vpc.OwnerId,
+		PublicSubnets:             publicSubnets,
+		PrivateSubnets:            privateSubnets,
+		PublicRouteTables:         resize(publicRouteTables),
+		PrivateRouteTables:        resize(privateRouteTables),
+		SecurityGroups:            securitygroups,
+		SecurityGroupDetails:      securitygroupDetails,
+		SubnetSetCapacity:         subnetSetCapacity,
+		TransitGateways:           transitGateways,
+		VpcEndpoints:              endpoints,
+		VpcPeeringConnections:     vpcPeeringConnections,
+		VpnConnections:            vpnConnections,
+		VpnGateway:                vpnGateway,
 	}
 
 	return v, nil
 }
 
-func resize[T []xfnd.StatusSubnets | []xfnd.StatusRouteTables](s T) T {
-	var (
-		max int
-	)
-	switch v := any(s).(type) {
+// selectVpc picks a single VPC from multiple matches according to the given
+// ambiguity policy. Without a policy, multiple matches are an error.
+func selectVpc(vpcs []ec2types.Vpc, policy *inp.AmbiguityPolicy) (vpc ec2types.Vpc, err error) {
+	if policy == nil || policy.Policy == "" || policy.Policy == inp.AmbiguityPolicyError {
+		err = errors.New("VPC lookup is ambiguous")
+		return
+	}
+
+	var found bool
+	switch policy.Policy {
+	case inp.AmbiguityPolicyNewest:
+		// EC2 does not report when a VPC was created so the newest VPC is
+		// determined from the RFC3339 timestamp held in the given tag. EC2
+		// doesn't guarantee the order VPCs are returned in so ties go to the
+		// lowest VPC ID.
+		if policy.TimestampTag == "" {
+			err = errors.New("timestampTag is required for the newest ambiguity policy")
+			return
+		}
+
+		var newest time.Time
+		for _, v := range vpcs {
+			for _, tag := range v.Tags {
+				if *tag.Key != policy.TimestampTag {
+					continue
+				}
+
+				t, e := time.Parse(time.RFC3339, aws.ToString(tag.Value))
+				if e == nil && (!found || t.After(newest) || (t.Equal(newest) && aws.ToString(v.VpcId) < aws.ToString(vpc.VpcId))) {
+					found, newest, vpc = true, t, v
+				}
+			}
+		}
+
+		if !found {
+			err = errors.Errorf("no VPC carries a valid %q timestamp tag", policy.TimestampTag)
+		}
+	case inp.AmbiguityPolicyPreferTag:
+		if policy.Tag == nil {
+			err = errors.New("tag is required for the preferTag ambiguity policy")
+			return
+		}
+
+		for _, v := range vpcs {
+			for _, tag := range v.Tags {
+				if *tag.Key != policy.Tag.Key || (policy.Tag.Value != "" && aws.ToString(tag.Value) != policy.Tag.Value) {
+					continue
+				}
+
+				if found {
+					err = errors.Errorf("more than one VPC carries the preferred tag %q", policy.Tag.Key)
+					return
+				}
+				found, vpc = true, v
+			}
+		}
+
+		if !found {
+			err = errors.Errorf("no VPC carries the preferred tag %q", policy.Tag.Key)
+		}
+	default:
+		err = errors.Errorf("unknown ambiguity policy %q", policy.Policy)
+	}
+	return
+}
+
+// resize drops trailing sets without any entries. Empty sets in between are
+// kept as empty maps so each list stays indexed by set number.
+func resize[T []xfnd.StatusSubnets | []xfnd.StatusRouteTables](s T) T {
+	var (
+		last int
+	)
+	switch v := any(s).(type) {
 	case []xfnd.StatusSubnets:
-		for _, sn := range v {
+		for i, sn := range v {
 			if len(sn) > 0 {
-				max++
+				last = i + 1
 			}
 		}
-		s = any(v[:max]).(T)
+
+		v = v[:last]
+		for i := range v {
+			if v[i] == nil {
+				v[i] = make(xfnd.StatusSubnets)
+			}
+		}
+		s = any(v).(T)
 	case []xfnd.StatusRouteTables:
-		for _, rt := range v {
+		for i, rt := range v {
 			if len(rt) > 0 {
-				max++
+				last = i + 1
+			}
+		}
+
+		v = v[:last]
+		for i := range v {
+			if v[i] == nil {
+				v[i] = make(xfnd.StatusRouteTables)
 			}
 		}
-		s = any(v[:max]).(T)
+		s = any(v).(T)
 	}
 	return s
 }
 
-func (f *Function) getSubnets(client AwsEc2Api, input *ec2.DescribeSubnetsInput, groupTag *string) (count int, subnets map[string]xfnd.AwsSubnet, err error) {
+// subnetCapacity calculates the IPv4 address capacity of a subnet. AWS
+// reserves the first four and the last address of every subnet, so these are
+// not counted towards the total. IPv6 only subnets have no capacity figures
+func subnetCapacity(cidr string, available int32, reservations []xfnd.SubnetCidrReservation) *xfnd.SubnetCapacity {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil || !prefix.Addr().Is4() {
+		return nil
+	}
+
+	var c xfnd.SubnetCapacity = xfnd.SubnetCapacity{
+		Total: int64(1)<<(32-prefix.Bits()) - 5,
+		Free:  int64(available),
+	}
+	c.Used = c.Total - c.Free
+
+	for _, r := range reservations {
+		if p, e := netip.ParsePrefix(r.Cidr); e == nil && p.Addr().Is4() {
+			c.Reserved += int64(1) << (32 - p.Bits())
+		}
+	}
+	return &c
+}
+
+// addCapacity adds the capacity of a subnet to a running total
+func addCapacity(total *xfnd.SubnetCapacity, c *xfnd.SubnetCapacity) {
+	if c == nil {
+		return
+	}
+	total.Total += c.Total
+	total.Used += c.Used
+	total.Free += c.Free
+	total.Reserved += c.Reserved
+}
+
+// awsNamed pairs a discovered resource with the name it is reported under
+type awsNamed[T any] struct {
+	name    string
+	details T
+}
+
+// awsRouting holds the routing components discovered for a single VPC.
+//
+// Everything is fetched in bulk for the VPC and keyed by ID so that subnets
+// can be joined against it in memory rather than calling the API per subnet
+type awsRouting struct {
+	// Route tables keyed by the ID of each subnet explicitly associated
+	subnetRouteTables map[string][]ec2types.RouteTable
+
+	// The main route table of the VPC used by subnets without an explicit
+	// association
+	mainRouteTable *ec2types.RouteTable
+
+	// NAT gateways keyed by NAT gateway ID
+	natGateways map[string]awsNamed[xfnd.NatGateway]
+
+	// Transit gateways keyed by transit gateway ID
+	transitGateways map[string]awsNamed[xfnd.TransitGateway]
+
+	// VPC peering connections keyed by peering connection ID
+	peeringConnections map[string]awsNamed[xfnd.PeeringConnection]
+}
+
+func (f *Function) getRouting(client AwsEc2Api, vpcId string, remote *inp.RemoteVpc) (routing awsRouting, err error) {
+	f.log.Info("Getting route tables", "vpc", vpcId)
+	routing = awsRouting{
+		subnetRouteTables:  make(map[string][]ec2types.RouteTable),
+		natGateways:        make(map[string]awsNamed[xfnd.NatGateway]),
+		transitGateways:    make(map[string]awsNamed[xfnd.TransitGateway]),
+		peeringConnections: make(map[string]awsNamed[xfnd.PeeringConnection]),
+	}
+
+	var routeTables *ec2.DescribeRouteTablesOutput
+	{
+		routeTables, err = GetRouteTables(context.Background(), client, &ec2.DescribeRouteTablesInput{
+			Filters: []ec2types.Filter{
+				{
+					Name:   aws.String("vpc-id"),
+					Values: []string{vpcId},
+				},
+			},
+		})
+		if err != nil {
+			f.log.Info("Got an error retrieving information about your route tables", "error", err)
+			return
+		}
+	}
+
+	var (
+		tgwIds []string
+		pcxIds []string
+		seen   map[string]bool = make(map[string]bool)
+	)
+	for _, rt := range routeTables.RouteTables {
+		rt := rt
+		for _, assoc := range rt.Associations {
+			if !isActiveAssociation(assoc) {
+				continue
+			}
+
+			if assoc.SubnetId != nil {
+				routing.subnetRouteTables[*assoc.SubnetId] = append(routing.subnetRouteTables[*assoc.SubnetId], rt)
+			}
+
+			if assoc.Main != nil && *assoc.Main {
+				routing.mainRouteTable = &rt
+			}
+		}
+
+		for _, r := range rt.Routes {
+			if r.TransitGatewayId != nil && !seen[*r.TransitGatewayId] {
+				seen[*r.TransitGatewayId] = true
+				tgwIds = append(tgwIds, *r.TransitGatewayId)
+			}
+
+			if r.VpcPeeringConnectionId != nil && !seen[*r.VpcPeeringConnectionId] {
+				seen[*r.VpcPeeringConnectionId] = true
+				pcxIds = append(pcxIds, *r.VpcPeeringConnectionId)
+			}
+		}
+	}
+
+	var e error
+	if routing.natGateways, e = f.getNatGateways(client, vpcId); e != nil {
+		f.log.Info("Error getting NAT Gateways - skipping", "error", e)
+	}
+
+	if len(tgwIds) > 0 {
+		if routing.transitGateways, e = f.getTransitGateways(client, vpcId, tgwIds, remote.TransitGatewayAttachments); e != nil {
+			f.log.Info("Error getting Transit Gateways - skipping", "error", e)
+		}
+	}
+
+	if len(pcxIds) > 0 {
+		if routing.peeringConnections, e = f.getVpcPeeringConnections(client, vpcId, pcxIds); e != nil {
+			f.log.Info("Error getting VPC Peering Connections - skipping", "error", e)
+		}
+	}
+
+	return
+}
+
+// getSubnets returns the subnets of the VPC keyed by name along with the
+// number of subnet sets, which is one more than the highest set number found
+func (f *Function) getSubnets(client AwsEc2Api, vpcId string, remote *inp.RemoteVpc) (count int, subnets map[string]xfnd.AwsSubnet, err error) {
 	f.log.Info("Getting subnets")
 	subnets = make(map[string]xfnd.AwsSubnet)
 
 	var subnetOutput *ec2.DescribeSubnetsOutput
 	{
-		subnetOutput, err = GetSubnets(context.Background(), client, input)
+		subnetOutput, err = GetSubnets(context.Background(), client, &ec2.DescribeSubnetsInput{
+			Filters: []ec2types.Filter{
+				{
+					Name:   aws.String("vpc-id"),
+					Values: []string{vpcId},
+				},
+			},
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	var routing awsRouting
+	{
+		routing, err = f.getRouting(client, vpcId, remote)
 		if err != nil {
 			return
 		}
 	}
 
-	var groups map[int]bool = make(map[int]bool)
-	groups[0] = true
+	// NAT gateways are placed in a subnet of the VPC being discovered which
+	// gives us the availability zone they are located in
+	var zones map[string]string = make(map[string]string, len(subnetOutput.Subnets))
+	for _, sn := range subnetOutput.Subnets {
+		zones[*sn.SubnetId] = *sn.AvailabilityZone
+	}
+
+	for id, ngw := range routing.natGateways {
+		ngw.details.AvailabilityZone = zones[ngw.details.SubnetID]
+		routing.natGateways[id] = ngw
+	}
+
+	// Subnets without a set number belong to set 0
+	count = 1
 
 	for _, sn := range subnetOutput.Subnets {
 		var name string
@@ -393,10 +1271,10 @@ func (f *Function) getSubnets(client AwsEc2Api, input *ec2.DescribeSubnetsInput,
 					name = *tag.Value
 				}
 
-				if *tag.Key == *groupTag {
-					if i, e := strconv.Atoi(*tag.Value); e == nil {
+				if *tag.Key == remote.GroupBy {
+					if i, e := strconv.Atoi(*tag.Value); e == nil && i >= 0 {
 						subnetSet = i
-						groups[i] = true
+						count = max(count, i+1)
 					}
 				}
 
@@ -405,43 +1283,57 @@ func (f *Function) getSubnets(client AwsEc2Api, input *ec2.DescribeSubnetsInput,
 
 		f.log.Info("Processing subnet", "sn", *sn.SubnetId, "name", name)
 		var s xfnd.AwsSubnet = xfnd.AwsSubnet{
-			ARN:                 *sn.SubnetArn,
-			ID:                  *sn.SubnetId,
-			AvailabilityZone:    *sn.AvailabilityZone,
-			CidrBlock:           *sn.CidrBlock,
-			IsPublic:            false,
-			IsIpv6:              false,
-			MapPublicIPOnLaunch: sn.MapPublicIpOnLaunch,
-			SubnetSet:           subnetSet,
+			ARN:                     *sn.SubnetArn,
+			ID:                      *sn.SubnetId,
+			AvailabilityZone:        *sn.AvailabilityZone,
+			AvailabilityZoneID:      aws.ToString(sn.AvailabilityZoneId),
+			AvailableIpAddressCount: aws.ToInt32(sn.AvailableIpAddressCount),
+			CidrBlock:               aws.ToString(sn.CidrBlock),
+			DefaultForAz:            aws.ToBool(sn.DefaultForAz),
+			IsPublic:                false,
+			IsIpv6:                  false,
+			Ipv6Native:              aws.ToBool(sn.Ipv6Native),
+			MapPublicIPOnLaunch:     sn.MapPublicIpOnLaunch,
+			SubnetSet:               subnetSet,
+		}
+
+		for _, cidr := range sn.Ipv6CidrBlockAssociationSet {
+			if cidr.Ipv6CidrBlockState != nil && cidr.Ipv6CidrBlockState.State != ec2types.SubnetCidrBlockStateCodeAssociated {
+				continue
+			}
+			s.IsIpv6 = true
+			s.Ipv6CidrBlock = aws.ToString(cidr.Ipv6CidrBlock)
+		}
+
+		// Reservations can only be looked up one subnet at a time so are
+		// only fetched when requested
+		if remote.Discover != nil && remote.Discover.SubnetCidrReservations {
+			var e error
+			if s.CidrReservations, e = f.getSubnetCidrReservations(client, s.ID); e != nil {
+				f.log.Info("Error getting subnet CIDR reservations - skipping", "sn", s.ID, "error", e)
+			}
 		}
+		s.Capacity = subnetCapacity(s.CidrBlock, s.AvailableIpAddressCount, s.CidrReservations)
 
 		s.RouteTables = make(map[string]xfnd.AwsRouteTable)
-		s.NatGateways = make(map[string]string)
+		s.NatGateways = make(map[string]xfnd.NatGateway)
 		s.TransitGateways = make(map[string]xfnd.TransitGateway)
 		s.VpcPeeringConnections = make(map[string]xfnd.PeeringConnection)
 
-		var routeTables *ec2.DescribeRouteTablesOutput
-		{
-			routeTables, err = GetRouteTables(context.TODO(), client, &ec2.DescribeRouteTablesInput{
-				Filters: []ec2types.Filter{
-					{
-						Name:   aws.String("association.subnet-id"),
-						Values: []string{*sn.SubnetId},
-					},
-				},
-			})
-			if err != nil {
-				f.log.Info("Got an error retrieving information about your route tables", "error", err)
-				return
+		var routeTables []ec2types.RouteTable = routing.subnetRouteTables[*sn.SubnetId]
+		if len(routeTables) == 0 {
+			// Subnets without an explicit association use the VPC main route
+			// table
+			if routing.mainRouteTable == nil {
+				f.log.Info("No route tables found for subnet", "sn", *sn.SubnetId)
+				return 0, nil, errors.New("No route tables found for subnet")
 			}
+			f.log.Info("Using main route table for subnet", "sn", *sn.SubnetId, "rt", *routing.mainRouteTable.RouteTableId)
+			routeTables = []ec2types.RouteTable{*routing.mainRouteTable}
+			s.ImplicitRouteTableAssociation = true
 		}
 
-		if len(routeTables.RouteTables) == 0 {
-			f.log.Info("No route tables found for subnet", "sn", *sn.SubnetId)
-			return 0, nil, errors.New("No route tables found for subnet")
-		}
-
-		for _, rt := range routeTables.RouteTables {
+		for _, rt := range routeTables {
 			var (
 				rtblName     string
 				associations []xfnd.AwsAssociation
@@ -463,9 +1355,22 @@ func (f *Function) getSubnets(client AwsEc2Api, input *ec2.DescribeSubnetsInput,
 					if assoc.SubnetId != nil && *assoc.SubnetId != *sn.SubnetId {
 						continue
 					}
+
+					if !isActiveAssociation(assoc) {
+						f.log.Info("Skipping inactive association", "assoc", *assoc.RouteTableAssociationId)
+						continue
+					}
+
 					f.log.Info("Processing association", "assoc", *assoc.RouteTableAssociationId)
 					var a xfnd.AwsAssociation = xfnd.AwsAssociation{
-						ID: *assoc.RouteTableAssociationId,
+						ID:           *assoc.RouteTableAssociationId,
+						Main:         aws.ToBool(assoc.Main),
+						RouteTableID: aws.ToString(assoc.RouteTableId),
+						SubnetID:     aws.ToString(assoc.SubnetId),
+					}
+
+					if assoc.AssociationState != nil {
+						a.State = string(assoc.AssociationState.State)
 					}
 
 					associations = append(associations, a)
@@ -476,52 +1381,40 @@ func (f *Function) getSubnets(client AwsEc2Api, input *ec2.DescribeSubnetsInput,
 				}
 
 				for _, r := range rt.Routes {
-					r := r
+					// Applies to both 0.0.0.0/0 and ::/0 routes
 					if r.GatewayId != nil && strings.HasPrefix(*r.GatewayId, "igw-") {
 						s.IsPublic = true
 						s.InternetGateway = *r.GatewayId
 					}
 
-					if r.NatGatewayId != nil {
-						var ngwname string
-						ngwname, err = f.getNatGateway(client, *r.NatGatewayId)
-						if err != nil {
-							f.log.Info("Error getting NAT Gateway - skipping", "error", err)
-						}
+					if r.GatewayId != nil && strings.HasPrefix(*r.GatewayId, "vgw-") {
+						s.VpnGateway = *r.GatewayId
+					}
 
-						if ngwname != "" {
+					if r.EgressOnlyInternetGatewayId != nil {
+						s.EgressOnlyInternetGateway = *r.EgressOnlyInternetGatewayId
+					}
+
+					if r.NatGatewayId != nil {
+						if ngw, ok := routing.natGateways[*r.NatGatewayId]; ok && ngw.name != "" {
+							var ngwname string = ngw.name
 							if !strings.HasSuffix(ngwname, s.AvailabilityZone) {
 								ngwname = ngwname + "-" + s.AvailabilityZone
 							}
-							s.NatGateways[ngwname] = *r.NatGatewayId
+							s.NatGateways[ngwname] = ngw.details
 						}
 					}
 
 					if r.TransitGatewayId != nil {
-						var tgwname string
-						var details xfnd.TransitGateway
-						tgwname, details, err = f.getTransitGateway(client, *r.TransitGatewayId)
-						if err != nil {
-							f.log.Info("Error getting Transit Gateway - skipping", "error", err)
-						}
-
-						if tgwname != "" {
-							s.TransitGateways[tgwname] = details
+						if tgw, ok := routing.transitGateways[*r.TransitGatewayId]; ok && tgw.name != "" {
+							s.TransitGateways[tgw.name] = tgw.details
 						}
 					}
 
 					if r.VpcPeeringConnectionId != nil {
-						var pcname string
-						var details xfnd.PeeringConnection
-						pcname, details, err = f.getVpcPeeringConnection(client, *r.VpcPeeringConnectionId)
-						if err != nil {
-							f.log.Info("Error getting VPC Peering Connection - skipping", "error", err)
-						}
-
-						if pcname != "" {
-							s.VpcPeeringConnections[pcname] = details
+						if pc, ok := routing.peeringConnections[*r.VpcPeeringConnectionId]; ok && pc.name != "" {
+							s.VpcPeeringConnections[pc.name] = pc.details
 						}
-
 					}
 				}
 			}
@@ -529,196 +1422,1279 @@ func (f *Function) getSubnets(client AwsEc2Api, input *ec2.DescribeSubnetsInput,
 			var rtbl xfnd.AwsRouteTable = xfnd.AwsRouteTable{
 				ID:           *rt.RouteTableId,
 				Associations: associations,
+				IsImplicit:   s.ImplicitRouteTableAssociation,
 				IsPublic:     s.IsPublic,
 				SubnetSet:    subnetSet,
 			}
-			rtbl.Routes = make(map[string]xfnd.AwsRoute)
+			rtbl.Routes = awsRoutes(rt.Routes)
+			for _, vgw := range rt.PropagatingVgws {
+				rtbl.PropagatingVpnGateways = append(rtbl.PropagatingVpnGateways, aws.ToString(vgw.GatewayId))
+			}
 			s.RouteTables[rtblName] = rtbl
 		}
 		subnets[name] = s
 	}
 
-	for _, g := range groups {
-		if g {
-			count++
-		}
+	return count, subnets, nil
+}
+
+// isActiveAssociation reports whether a route table association is in use.
+// Associations that are disassociated or failed are ignored for discovery.
+func isActiveAssociation(assoc ec2types.RouteTableAssociation) bool {
+	if assoc.AssociationState == nil {
+		return true
 	}
 
-	return count, subnets, nil
+	switch assoc.AssociationState.State {
+	case ec2types.RouteTableAssociationStateCodeDisassociated, ec2types.RouteTableAssociationStateCodeFailed:
+		return false
+	}
+	return true
+}
+
+// awsRoutes converts the routes of a route table into a map keyed by the
+// route destination
+func awsRoutes(routes []ec2types.Route) map[string]xfnd.AwsRoute {
+	var out map[string]xfnd.AwsRoute = make(map[string]xfnd.AwsRoute, len(routes))
+	for _, r := range routes {
+		var id string
+		switch {
+		case r.DestinationCidrBlock != nil:
+			id = *r.DestinationCidrBlock
+		case r.DestinationIpv6CidrBlock != nil:
+			id = *r.DestinationIpv6CidrBlock
+		case r.DestinationPrefixListId != nil:
+			id = *r.DestinationPrefixListId
+		default:
+			continue
+		}
+
+		out[id] = xfnd.AwsRoute{
+			ID:                          id,
+			DestinationCidrBlock:        aws.ToString(r.DestinationCidrBlock),
+			DestinationIpv6CidrBlock:    aws.ToString(r.DestinationIpv6CidrBlock),
+			GatewayID:                   aws.ToString(r.GatewayId),
+			InstanceID:                  aws.ToString(r.InstanceId),
+			NatGatewayID:                aws.ToString(r.NatGatewayId),
+			NetworkInterfaceID:          aws.ToString(r.NetworkInterfaceId),
+			TransitGateway:              aws.ToString(r.TransitGatewayId),
+			VpcPeeringConnectionID:      aws.ToString(r.VpcPeeringConnectionId),
+			LocalGatewayID:              aws.ToString(r.LocalGatewayId),
+			CarrierGatewayID:            aws.ToString(r.CarrierGatewayId),
+			PrefixListID:                aws.ToString(r.DestinationPrefixListId),
+			EgressOnlyInternetGatewayID: aws.ToString(r.EgressOnlyInternetGatewayId),
+		}
+	}
+	return out
 }
 
-func (f *Function) getNatGateway(client AwsEc2Api, ngwId string) (name string, err error) {
-	f.log.Info("Getting NAT Gateway", "ngw", ngwId)
+// getNatGateways returns all NAT gateways in the VPC keyed by ID. NAT
+// gateways that have been deleted or failed to create are excluded.
+func (f *Function) getNatGateways(client AwsEc2Api, vpcId string) (ngws map[string]awsNamed[xfnd.NatGateway], err error) {
+	f.log.Info("Getting NAT Gateways", "vpc", vpcId)
+	ngws = make(map[string]awsNamed[xfnd.NatGateway])
 	ngw, err := GetNatGateways(context.Background(), client, &ec2.DescribeNatGatewaysInput{
-		NatGatewayIds: []string{ngwId},
+		Filter: []ec2types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcId},
+			},
+		},
 	})
 	if err != nil {
 		return
 	}
 
 	for _, n := range ngw.NatGateways {
+		switch n.State {
+		case ec2types.NatGatewayStateDeleted, ec2types.NatGatewayStateFailed:
+			f.log.Info("Skipping NAT Gateway", "ngw", *n.NatGatewayId, "state", n.State)
+			continue
+		}
+
+		var name string
 		for _, tag := range n.Tags {
 			if *tag.Key == nametag {
 				name = *tag.Value
 			}
 		}
+
+		var details xfnd.NatGateway = xfnd.NatGateway{
+			ID:               *n.NatGatewayId,
+			Addresses:        make([]xfnd.NatGatewayAddress, 0, len(n.NatGatewayAddresses)),
+			ConnectivityType: string(n.ConnectivityType),
+			State:            string(n.State),
+			SubnetID:         aws.ToString(n.SubnetId),
+		}
+
+		for _, a := range n.NatGatewayAddresses {
+			details.Addresses = append(details.Addresses, xfnd.NatGatewayAddress{
+				AllocationID:       aws.ToString(a.AllocationId),
+				IsPrimary:          aws.ToBool(a.IsPrimary),
+				NetworkInterfaceID: aws.ToString(a.NetworkInterfaceId),
+				PrivateIP:          aws.ToString(a.PrivateIp),
+				PublicIP:           aws.ToString(a.PublicIp),
+			})
+		}
+
+		ngws[*n.NatGatewayId] = awsNamed[xfnd.NatGateway]{
+			name:    name,
+			details: details,
+		}
 	}
 	return
 }
 
-func (f *Function) getTransitGateway(client AwsEc2Api, tgwId string) (name string, details xfnd.TransitGateway, err error) {
-	f.log.Info("Getting Transit Gateway", "tgw", tgwId)
+// getTransitGateways returns the details of the requested transit gateways
+// keyed by ID. Attachments and route tables for all gateways are fetched in a
+// single call each and then grouped by the transit gateway they belong to.
+//
+// Unless requested otherwise, only the attachment of the VPC being discovered
+// is reported as shared transit gateways may carry hundreds of attachments.
+func (f *Function) getTransitGateways(client AwsEc2Api, vpcId string, tgwIds []string, opts *inp.TransitGatewayAttachmentOptions) (tgws map[string]awsNamed[xfnd.TransitGateway], err error) {
+	f.log.Info("Getting Transit Gateways", "tgws", tgwIds)
+	tgws = make(map[string]awsNamed[xfnd.TransitGateway])
+
+	var filters []ec2types.Filter = []ec2types.Filter{
+		{
+			Name:   aws.String("transit-gateway-id"),
+			Values: tgwIds,
+		},
+	}
+
 	tgw, err := GetTransitGateways(context.Background(), client, &ec2.DescribeTransitGatewaysInput{
-		TransitGatewayIds: []string{tgwId},
+		Filters: filters,
 	})
 	if err != nil {
 		return
 	}
 
-	details = xfnd.TransitGateway{
-		ID:          tgwId,
-		Attachments: make(map[string]xfnd.TransitGatewayAttachment),
-		RouteTables: make(map[string]xfnd.TransitGatewayRouteTable),
-	}
-
-	// This should be a loop of exactly one item,
-	// the Transit Gateway we are looking for.
 	for i, n := range tgw.TransitGateways {
-		details.ARN = *n.TransitGatewayArn
-
-		name = "no-name-" + strconv.Itoa(i)
+		var name string = "no-name-" + strconv.Itoa(i)
 		for _, tag := range n.Tags {
 			if *tag.Key == nametag {
 				name = *tag.Value
 			}
 		}
-		var attachments *ec2.DescribeTransitGatewayAttachmentsOutput
-		{
-			f.log.Info("Getting Transit Gateway Attachments", "tgw", tgwId)
-			attachments, err = GetTransitGatewayAttachments(context.Background(), client, &ec2.DescribeTransitGatewayAttachmentsInput{
-				Filters: []ec2types.Filter{
-					{
-						Name:   aws.String("transit-gateway-id"),
-						Values: []string{tgwId},
-					},
-				},
+
+		tgws[*n.TransitGatewayId] = awsNamed[xfnd.TransitGateway]{
+			name: name,
+			details: xfnd.TransitGateway{
+				ARN:         *n.TransitGatewayArn,
+				ID:          *n.TransitGatewayId,
+				Attachments: make(map[string]xfnd.TransitGatewayAttachment),
+				RouteTables: make(map[string]xfnd.TransitGatewayRouteTable),
+			},
+		}
+	}
+
+	var attachments *ec2.DescribeTransitGatewayAttachmentsOutput
+	{
+		var (
+			all          bool                        = opts != nil && opts.All
+			types        map[string]bool             = make(map[string]bool)
+			attachFilter []ec2types.Filter           = filters
+			peerings     map[string]awsNamed[string] = make(map[string]awsNamed[string])
+		)
+
+		if opts != nil {
+			for _, t := range opts.Types {
+				types[t] = true
+			}
+		}
+
+		// Without additional types, the VPC attachment can be selected by
+		// the API rather than filtering it out of every attachment locally
+		if !all && len(types) == 0 {
+			attachFilter = append(attachFilter, ec2types.Filter{
+				Name:   aws.String("resource-id"),
+				Values: []string{vpcId},
 			})
-			if err != nil {
-				f.log.Info("Got an error retrieving information about your Transit Gateway attachments", "error", err)
-				return
+		}
+
+		f.log.Info("Getting Transit Gateway Attachments", "tgws", tgwIds)
+		attachments, err = GetTransitGatewayAttachments(context.Background(), client, &ec2.DescribeTransitGatewayAttachmentsInput{
+			Filters: attachFilter,
+		})
+		if err != nil {
+			f.log.Info("Got an error retrieving information about your Transit Gateway attachments", "error", err)
+			return
+		}
+
+		var index map[string]int = make(map[string]int)
+		for _, a := range attachments.TransitGatewayAttachments {
+			t, ok := tgws[*a.TransitGatewayId]
+			if !ok {
+				continue
 			}
 
-			for i, a := range attachments.TransitGatewayAttachments {
-				var tgwName string = "no-name-" + strconv.Itoa(i)
-				{
-					for _, tag := range a.Tags {
-						if *tag.Key == nametag {
-							tgwName = *tag.Value
-						}
+			if !all && aws.ToString(a.ResourceId) != vpcId && !types[string(a.ResourceType)] {
+				continue
+			}
+
+			var tgwName string = "no-name-" + strconv.Itoa(index[*a.TransitGatewayId])
+			{
+				index[*a.TransitGatewayId]++
+				for _, tag := range a.Tags {
+					if *tag.Key == nametag {
+						tgwName = *tag.Value
 					}
 				}
+			}
 
-				details.Attachments[tgwName] = xfnd.TransitGatewayAttachment{
-					ID:         *a.TransitGatewayAttachmentId,
-					ResourceID: *a.ResourceId,
-					Type:       string(a.ResourceType),
-				}
+			var attachment xfnd.TransitGatewayAttachment = xfnd.TransitGatewayAttachment{
+				ID:         *a.TransitGatewayAttachmentId,
+				ResourceID: *a.ResourceId,
+				Type:       string(a.ResourceType),
+			}
 
-				if a.Association != nil {
-					attachments := details.Attachments[tgwName]
-					attachments.RouteTableID = *a.Association.TransitGatewayRouteTableId
-					details.Attachments[tgwName] = attachments
-				}
+			if a.Association != nil {
+				attachment.RouteTableID = *a.Association.TransitGatewayRouteTableId
 			}
+			t.details.Attachments[tgwName] = attachment
 
-			var rtbs *ec2.DescribeTransitGatewayRouteTablesOutput
-			{
-				f.log.Info("Getting Transit Gateway Route Tables", "tgw", tgwId)
-				rtbs, err = GetTransitGatewayRouteTables(context.Background(), client, &ec2.DescribeTransitGatewayRouteTablesInput{
-					Filters: []ec2types.Filter{
-						{
-							Name:   aws.String("transit-gateway-id"),
-							Values: []string{tgwId},
-						},
-					},
-				})
-				if err != nil {
-					f.log.Info("Got an error retrieving information about your Transit Gateway route tables", "error", err)
-					return
+			if a.ResourceType == ec2types.TransitGatewayAttachmentResourceTypePeering {
+				peerings[attachment.ID] = awsNamed[string]{
+					name:    tgwName,
+					details: *a.TransitGatewayId,
 				}
+			}
+		}
 
-				for i, rtb := range rtbs.TransitGatewayRouteTables {
-					var rtbName string = "no-name-" + strconv.Itoa(i)
-					{
-						for _, tag := range rtb.Tags {
-							if *tag.Key == nametag {
-								rtbName = *tag.Value
-							}
-						}
-					}
+		if len(peerings) > 0 {
+			if e := f.getTransitGatewayPeerings(client, peerings, tgws); e != nil {
+				f.log.Info("Error getting Transit Gateway Peering Attachments - skipping", "error", e)
+			}
+		}
+	}
 
-					details.RouteTables[rtbName] = xfnd.TransitGatewayRouteTable{
-						ID:                 *rtb.TransitGatewayRouteTableId,
-						DefaultAssociation: *rtb.DefaultAssociationRouteTable,
-						DefaultPropagation: *rtb.DefaultPropagationRouteTable,
+	var rtbs *ec2.DescribeTransitGatewayRouteTablesOutput
+	{
+		f.log.Info("Getting Transit Gateway Route Tables", "tgws", tgwIds)
+		rtbs, err = GetTransitGatewayRouteTables(context.Background(), client, &ec2.DescribeTransitGatewayRouteTablesInput{
+			Filters: filters,
+		})
+		if err != nil {
+			f.log.Info("Got an error retrieving information about your Transit Gateway route tables", "error", err)
+			return
+		}
+
+		var index map[string]int = make(map[string]int)
+		for _, rtb := range rtbs.TransitGatewayRouteTables {
+			t, ok := tgws[*rtb.TransitGatewayId]
+			if !ok {
+				continue
+			}
+
+			var rtbName string = "no-name-" + strconv.Itoa(index[*rtb.TransitGatewayId])
+			{
+				index[*rtb.TransitGatewayId]++
+				for _, tag := range rtb.Tags {
+					if *tag.Key == nametag {
+						rtbName = *tag.Value
 					}
 				}
 			}
+
+			var table xfnd.TransitGatewayRouteTable = xfnd.TransitGatewayRouteTable{
+				ID:                 *rtb.TransitGatewayRouteTableId,
+				DefaultAssociation: *rtb.DefaultAssociationRouteTable,
+				DefaultPropagation: *rtb.DefaultPropagationRouteTable,
+			}
+
+			if e := f.getTransitGatewayRouteTableDetails(client, &table); e != nil {
+				f.log.Info("Error getting Transit Gateway Route Table details - skipping", "rtb", table.ID, "error", e)
+			}
+			t.details.RouteTables[rtbName] = table
 		}
 	}
-	f.log.Info("Transit Gateway", "tgw", tgwId, "name", name, "details", details)
+
+	for id, t := range tgws {
+		f.log.Info("Transit Gateway", "tgw", id, "name", t.name, "details", t.details)
+	}
 	return
 }
 
-func (f *Function) getVpcPeeringConnection(client AwsEc2Api, pcId string) (name string, details xfnd.PeeringConnection, err error) {
-	f.log.Info("Getting VPC Peering Connection", "pc", pcId)
-	details = xfnd.PeeringConnection{
-		ID: pcId,
+// getTransitGatewayPeerings resolves the accepter and requester side of
+// transit gateway peering attachments. Peerings maps each attachment ID onto
+// the name it is reported under and the transit gateway it belongs to.
+func (f *Function) getTransitGatewayPeerings(client AwsEc2Api, peerings map[string]awsNamed[string], tgws map[string]awsNamed[xfnd.TransitGateway]) (err error) {
+	var ids []string = make([]string, 0, len(peerings))
+	for id := range peerings {
+		ids = append(ids, id)
 	}
 
-	pc, err := GetVpcPeeringConnections(context.Background(), client, &ec2.DescribeVpcPeeringConnectionsInput{
-		VpcPeeringConnectionIds: []string{pcId},
+	f.log.Info("Getting Transit Gateway Peering Attachments", "attachments", ids)
+	output, err := GetTransitGatewayPeeringAttachments(context.Background(), client, &ec2.DescribeTransitGatewayPeeringAttachmentsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("transit-gateway-attachment-id"),
+				Values: ids,
+			},
+		},
 	})
 	if err != nil {
 		return
 	}
 
-	// This should be a loop of exactly one item,
-	// the VPC Peering Connection we are looking for.
-	for _, n := range pc.VpcPeeringConnections {
-		for _, tag := range n.Tags {
-			if *tag.Key == nametag {
-				name = *tag.Value
-			}
+	for _, p := range output.TransitGatewayPeeringAttachments {
+		ref, ok := peerings[aws.ToString(p.TransitGatewayAttachmentId)]
+		if !ok {
+			continue
 		}
 
-		if n.RequesterVpcInfo != nil {
-			details.ARN = fmt.Sprintf("arn:aws:ec2:%s:%s:vpc-peering-connection/%s", *n.RequesterVpcInfo.Region, *n.RequesterVpcInfo.OwnerId, pcId)
+		var peering xfnd.TransitGatewayPeering = xfnd.TransitGatewayPeering{
+			Accepter:  transitGatewayPeeringSide(p.AccepterTgwInfo),
+			Requester: transitGatewayPeeringSide(p.RequesterTgwInfo),
+			State:     string(p.State),
+		}
+
+		if p.Status != nil {
+			peering.StatusMessage = aws.ToString(p.Status.Message)
 		}
+
+		attachment := tgws[ref.details].details.Attachments[ref.name]
+		attachment.Peering = &peering
+		tgws[ref.details].details.Attachments[ref.name] = attachment
 	}
 	return
 }
 
-func (f *Function) getSecurityGroups(client AwsEc2Api, vpcId string) (sgs map[string]string, err error) {
-	f.log.Info("Getting security groups")
-	sgs = make(map[string]string)
-	securitygroups, err := GetSecurityGroups(context.Background(), client, &ec2.DescribeSecurityGroupsInput{
-		Filters: []ec2types.Filter{
-			{
-				Name:   aws.String("vpc-id"),
-				Values: []string{vpcId},
-			},
-		},
-	})
+// transitGatewayPeeringSide converts one side of a transit gateway peering
+func transitGatewayPeeringSide(info *ec2types.PeeringTgwInfo) *xfnd.TransitGatewayPeeringSide {
+	if info == nil {
+		return nil
+	}
 
+	return &xfnd.TransitGatewayPeeringSide{
+		OwnerID:          aws.ToString(info.OwnerId),
+		Region:           aws.ToString(info.Region),
+		TransitGatewayID: aws.ToString(info.TransitGatewayId),
+	}
+}
+
+// getTransitGatewayRouteTableDetails adds the routes, associations and
+// propagations of a transit gateway route table to the given table
+func (f *Function) getTransitGatewayRouteTableDetails(client AwsEc2Api, table *xfnd.TransitGatewayRouteTable) (err error) {
+	f.log.Info("Getting Transit Gateway Route Table details", "rtb", table.ID)
+	table.Routes = make(map[string]xfnd.TransitGatewayRoute)
+	table.Associations = make([]xfnd.TransitGatewayRouteTableAttachment, 0)
+	table.Propagations = make([]xfnd.TransitGatewayRouteTableAttachment, 0)
+
+	var routes *ec2.SearchTransitGatewayRoutesOutput
+	{
+		// Search requires at least one filter. Matching on every route type
+		// returns all routes in the table.
+		routes, err = SearchTransitGatewayRoutes(context.Background(), client, &ec2.SearchTransitGatewayRoutesInput{
+			TransitGatewayRouteTableId: aws.String(table.ID),
+			Filters: []ec2types.Filter{
+				{
+					Name:   aws.String("type"),
+					Values: []string{string(ec2types.TransitGatewayRouteTypeStatic), string(ec2types.TransitGatewayRouteTypePropagated)},
+				},
+			},
+			MaxResults: aws.Int32(1000),
+		})
+		if err != nil {
+			return
+		}
+
+		// SearchTransitGatewayRoutes is not paginated. Tables holding more
+		// routes than a single search returns are flagged as incomplete
+		if aws.ToBool(routes.AdditionalRoutesAvailable) {
+			f.log.Info("Transit Gateway Route Table has more routes than can be returned", "rtb", table.ID)
+			table.RoutesIncomplete = true
+		}
+
+		for i, r := range routes.Routes {
+			var route xfnd.TransitGatewayRoute = xfnd.TransitGatewayRoute{
+				DestinationCidrBlock: aws.ToString(r.DestinationCidrBlock),
+				PrefixListID:         aws.ToString(r.PrefixListId),
+				State:                string(r.State),
+				Type:                 string(r.Type),
+				Attachments:          make([]xfnd.TransitGatewayRouteTableAttachment, 0, len(r.TransitGatewayAttachments)),
+			}
+
+			for _, a := range r.TransitGatewayAttachments {
+				route.Attachments = append(route.Attachments, xfnd.TransitGatewayRouteTableAttachment{
+					ID:         aws.ToString(a.TransitGatewayAttachmentId),
+					ResourceID: aws.ToString(a.ResourceId),
+					Type:       string(a.ResourceType),
+				})
+			}
+
+			var id string = route.DestinationCidrBlock
+			if id == "" {
+				id = route.PrefixListID
+			}
+
+			// Routes without a destination are kept under a positional key
+			// so they cannot overwrite each other
+			if id == "" {
+				id = fmt.Sprintf("route-%d", i)
+			}
+			table.Routes[id] = route
+		}
+	}
+
+	var associations *ec2.GetTransitGatewayRouteTableAssociationsOutput
+	{
+		associations, err = GetTransitGatewayRouteTableAssociations(context.Background(), client, &ec2.GetTransitGatewayRouteTableAssociationsInput{
+			TransitGatewayRouteTableId: aws.String(table.ID),
+		})
+		if err != nil {
+			return
+		}
+
+		for _, a := range associations.Associations {
+			table.Associations = append(table.Associations, xfnd.TransitGatewayRouteTableAttachment{
+				ID:         aws.ToString(a.TransitGatewayAttachmentId),
+				ResourceID: aws.ToString(a.ResourceId),
+				State:      string(a.State),
+				Type:       string(a.ResourceType),
+			})
+		}
+	}
+
+	var propagations *ec2.GetTransitGatewayRouteTablePropagationsOutput
+	{
+		propagations, err = GetTransitGatewayRouteTablePropagations(context.Background(), client, &ec2.GetTransitGatewayRouteTablePropagationsInput{
+			TransitGatewayRouteTableId: aws.String(table.ID),
+		})
+		if err != nil {
+			return
+		}
+
+		for _, p := range propagations.TransitGatewayRouteTablePropagations {
+			table.Propagations = append(table.Propagations, xfnd.TransitGatewayRouteTableAttachment{
+				ID:         aws.ToString(p.TransitGatewayAttachmentId),
+				ResourceID: aws.ToString(p.ResourceId),
+				State:      string(p.State),
+				Type:       string(p.ResourceType),
+			})
+		}
+	}
+	return
+}
+
+// getVpcPeeringConnections returns the details of the requested VPC peering
+// connections keyed by ID. The side is reported relative to the VPC being
+// discovered.
+func (f *Function) getVpcPeeringConnections(client AwsEc2Api, vpcId string, pcIds []string) (pcs map[string]awsNamed[xfnd.PeeringConnection], err error) {
+	f.log.Info("Getting VPC Peering Connections", "pcs", pcIds)
+	pcs = make(map[string]awsNamed[xfnd.PeeringConnection])
+
+	pc, err := GetVpcPeeringConnections(context.Background(), client, &ec2.DescribeVpcPeeringConnectionsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("vpc-peering-connection-id"),
+				Values: pcIds,
+			},
+		},
+	})
 	if err != nil {
 		return
 	}
 
+	for _, n := range pc.VpcPeeringConnections {
+		var (
+			name    string
+			details xfnd.PeeringConnection = xfnd.PeeringConnection{
+				ID: *n.VpcPeeringConnectionId,
+			}
+		)
+		for _, tag := range n.Tags {
+			if *tag.Key == nametag {
+				name = *tag.Value
+			}
+		}
+
+		if n.RequesterVpcInfo != nil {
+			// Peering connections cannot span partitions so the partition of
+			// the requester applies to both sides
+			var e error
+			if details.ARN, e = awsArn("ec2", aws.ToString(n.RequesterVpcInfo.Region), aws.ToString(n.RequesterVpcInfo.OwnerId), "vpc-peering-connection/"+*n.VpcPeeringConnectionId); e != nil {
+				f.log.Info("Cannot build ARN for VPC Peering Connection", "pc", *n.VpcPeeringConnectionId, "error", e)
+			}
+			details.Requester = peeringConnectionVpc(n.RequesterVpcInfo)
+			if aws.ToString(n.RequesterVpcInfo.VpcId) == vpcId {
+				details.Side = "requester"
+			}
+		}
+
+		if n.AccepterVpcInfo != nil {
+			details.Accepter = peeringConnectionVpc(n.AccepterVpcInfo)
+			if aws.ToString(n.AccepterVpcInfo.VpcId) == vpcId {
+				details.Side = "accepter"
+			}
+		}
+
+		if n.Status != nil {
+			details.Status = string(n.Status.Code)
+			details.StatusMessage = aws.ToString(n.Status.Message)
+		}
+
+		pcs[*n.VpcPeeringConnectionId] = awsNamed[xfnd.PeeringConnection]{
+			name:    name,
+			details: details,
+		}
+	}
+	return
+}
+
+// peeringConnectionVpc converts one side of a VPC peering connection
+func peeringConnectionVpc(info *ec2types.VpcPeeringConnectionVpcInfo) *xfnd.PeeringConnectionVpc {
+	var vpc xfnd.PeeringConnectionVpc = xfnd.PeeringConnectionVpc{
+		CidrBlock: aws.ToString(info.CidrBlock),
+		OwnerID:   aws.ToString(info.OwnerId),
+		Region:    aws.ToString(info.Region),
+		VpcID:     aws.ToString(info.VpcId),
+	}
+
+	for _, c := range info.CidrBlockSet {
+		vpc.CidrBlocks = append(vpc.CidrBlocks, aws.ToString(c.CidrBlock))
+	}
+
+	for _, c := range info.Ipv6CidrBlockSet {
+		vpc.Ipv6CidrBlocks = append(vpc.Ipv6CidrBlocks, aws.ToString(c.Ipv6CidrBlock))
+	}
+
+	if info.PeeringOptions != nil {
+		vpc.AllowDnsResolutionFromRemoteVpc = aws.ToBool(info.PeeringOptions.AllowDnsResolutionFromRemoteVpc)
+	}
+	return &vpc
+}
+
+// getSecurityGroups returns a map of security group names to IDs. When
+// details are requested, the description, tags and rules of each group are
+// returned keyed by group name as well.
+func (f *Function) getSecurityGroups(client AwsEc2Api, vpcId string, opts *inp.SecurityGroupOptions) (sgs map[string]string, details map[string]xfnd.SecurityGroup, err error) {
+	f.log.Info("Getting security groups")
+	sgs = make(map[string]string)
+
+	var filters []ec2types.Filter = []ec2types.Filter{
+		{
+			Name:   aws.String("vpc-id"),
+			Values: []string{vpcId},
+		},
+	}
+
+	var names []string
+	if opts != nil {
+		names = opts.Names
+		filters = append(filters, tagFilters(opts.Tags)...)
+
+		if len(names) > 0 {
+			filters = append(filters, ec2types.Filter{
+				Name:   aws.String("group-name"),
+				Values: names,
+			})
+		}
+	}
+
+	securitygroups, err := GetSecurityGroups(context.Background(), client, &ec2.DescribeSecurityGroupsInput{
+		Filters: filters,
+	})
+
+	if err != nil {
+		return
+	}
+
+	if opts != nil && opts.Details {
+		details = make(map[string]xfnd.SecurityGroup)
+	}
+
+	var patterns []*regexp.Regexp = globRegexps(names)
 	for _, sg := range securitygroups.SecurityGroups {
+		if !matchesAny(patterns, *sg.GroupName) {
+			continue
+		}
+
 		f.log.Info("Processing security group", "sg", *sg.GroupId)
 		sgs[*sg.GroupName] = *sg.GroupId
+
+		if details == nil {
+			continue
+		}
+
+		var group xfnd.SecurityGroup = xfnd.SecurityGroup{
+			ID:          *sg.GroupId,
+			Description: aws.ToString(sg.Description),
+			Egress:      securityGroupRules(sg.IpPermissionsEgress),
+			Ingress:     securityGroupRules(sg.IpPermissions),
+			Tags:        make(map[string]string, len(sg.Tags)),
+		}
+
+		for _, tag := range sg.Tags {
+			group.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+		details[*sg.GroupName] = group
+	}
+
+	return
+}
+
+// matchesAny reports whether name matches any of the given patterns. An empty
+// list of patterns matches everything.
+func matchesAny(patterns []*regexp.Regexp, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, p := range patterns {
+		if p.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// globRegexps compiles a list of EC2 filter wildcard patterns.
+//
+// Patterns are matched the same way as EC2 filter values so that the local
+// check agrees with the server side filter: `*` matches any sequence of
+// characters including `/`, `?` matches a single character and `\` escapes
+// the character following it.
+func globRegexps(patterns []string) []*regexp.Regexp {
+	var compiled []*regexp.Regexp = make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		compiled = append(compiled, globRegexp(p))
+	}
+	return compiled
+}
+
+// globRegexp converts an EC2 filter wildcard pattern into an anchored regular
+// expression
+func globRegexp(pattern string) *regexp.Regexp {
+	var (
+		b       strings.Builder
+		escaped bool
+	)
+
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '*':
+			b.WriteString(".*")
+		case r == '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	if escaped {
+		b.WriteString(regexp.QuoteMeta("\\"))
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// securityGroupRules converts EC2 IP permissions into security group rules
+func securityGroupRules(permissions []ec2types.IpPermission) []xfnd.SecurityGroupRule {
+	var rules []xfnd.SecurityGroupRule = make([]xfnd.SecurityGroupRule, 0, len(permissions))
+	for _, p := range permissions {
+		var rule xfnd.SecurityGroupRule = xfnd.SecurityGroupRule{
+			FromPort: p.FromPort,
+			Protocol: aws.ToString(p.IpProtocol),
+			ToPort:   p.ToPort,
+		}
+
+		for _, r := range p.IpRanges {
+			rule.CidrBlocks = append(rule.CidrBlocks, aws.ToString(r.CidrIp))
+		}
+
+		for _, r := range p.Ipv6Ranges {
+			rule.Ipv6CidrBlocks = append(rule.Ipv6CidrBlocks, aws.ToString(r.CidrIpv6))
+		}
+
+		for _, pl := range p.PrefixListIds {
+			rule.PrefixListIDs = append(rule.PrefixListIDs, aws.ToString(pl.PrefixListId))
+		}
+
+		for _, g := range p.UserIdGroupPairs {
+			rule.SecurityGroups = append(rule.SecurityGroups, xfnd.SecurityGroupReference{
+				ID:      aws.ToString(g.GroupId),
+				OwnerID: aws.ToString(g.UserId),
+				VpcID:   aws.ToString(g.VpcId),
+			})
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// getVpcEndpoints returns the VPC endpoints defined in the VPC grouped by the
+// name of the service they connect to. A service can be reached through more
+// than one endpoint, for example S3 through both a gateway and an interface
+// endpoint, so every endpoint of the service is kept ordered by ID
+func (f *Function) getVpcEndpoints(client AwsEc2Api, vpcId string) (endpoints map[string][]xfnd.VpcEndpoint, err error) {
+	f.log.Info("Getting VPC endpoints", "vpc", vpcId)
+	endpoints = make(map[string][]xfnd.VpcEndpoint)
+	vpce, err := GetVpcEndpoints(context.Background(), client, &ec2.DescribeVpcEndpointsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcId},
+			},
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	for _, e := range vpce.VpcEndpoints {
+		f.log.Info("Processing VPC endpoint", "vpce", *e.VpcEndpointId, "service", aws.ToString(e.ServiceName))
+		var endpoint xfnd.VpcEndpoint = xfnd.VpcEndpoint{
+			ID:             *e.VpcEndpointId,
+			Type:           string(e.VpcEndpointType),
+			State:          string(e.State),
+			DnsEntries:     make([]xfnd.VpcEndpointDnsEntry, 0, len(e.DnsEntries)),
+			RouteTableIDs:  e.RouteTableIds,
+			SecurityGroups: make(map[string]string, len(e.Groups)),
+			SubnetIDs:      e.SubnetIds,
+		}
+
+		for _, d := range e.DnsEntries {
+			endpoint.DnsEntries = append(endpoint.DnsEntries, xfnd.VpcEndpointDnsEntry{
+				DnsName:      aws.ToString(d.DnsName),
+				HostedZoneID: aws.ToString(d.HostedZoneId),
+			})
+		}
+
+		for _, g := range e.Groups {
+			endpoint.SecurityGroups[aws.ToString(g.GroupName)] = aws.ToString(g.GroupId)
+		}
+
+		var service string = aws.ToString(e.ServiceName)
+		endpoints[service] = append(endpoints[service], endpoint)
+	}
+
+	for _, list := range endpoints {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].ID < list[j].ID
+		})
+	}
+	return
+}
+
+// getNetworkAcls returns the network ACLs defined in the VPC keyed by ID
+// along with a map of subnet IDs to the ID of the network ACL they are
+// associated with
+func (f *Function) getNetworkAcls(client AwsEc2Api, vpcId string) (nacls map[string]xfnd.NetworkAcl, subnets map[string]string, err error) {
+	f.log.Info("Getting network ACLs", "vpc", vpcId)
+	nacls = make(map[string]xfnd.NetworkAcl)
+	subnets = make(map[string]string)
+	acls, err := GetNetworkAcls(context.Background(), client, &ec2.DescribeNetworkAclsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcId},
+			},
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	for _, a := range acls.NetworkAcls {
+		f.log.Info("Processing network ACL", "acl", *a.NetworkAclId)
+		var acl xfnd.NetworkAcl = xfnd.NetworkAcl{
+			ID:        *a.NetworkAclId,
+			IsDefault: aws.ToBool(a.IsDefault),
+			Egress:    make([]xfnd.NetworkAclEntry, 0),
+			Ingress:   make([]xfnd.NetworkAclEntry, 0),
+			SubnetIDs: make([]string, 0, len(a.Associations)),
+		}
+
+		for _, tag := range a.Tags {
+			if *tag.Key == nametag {
+				acl.Name = *tag.Value
+			}
+		}
+
+		for _, assoc := range a.Associations {
+			if assoc.SubnetId == nil {
+				continue
+			}
+			acl.SubnetIDs = append(acl.SubnetIDs, *assoc.SubnetId)
+			subnets[*assoc.SubnetId] = acl.ID
+		}
+
+		for _, e := range a.Entries {
+			var entry xfnd.NetworkAclEntry = xfnd.NetworkAclEntry{
+				CidrBlock:     aws.ToString(e.CidrBlock),
+				Ipv6CidrBlock: aws.ToString(e.Ipv6CidrBlock),
+				Protocol:      aws.ToString(e.Protocol),
+				RuleAction:    string(e.RuleAction),
+				RuleNumber:    aws.ToInt32(e.RuleNumber),
+			}
+
+			if e.PortRange != nil {
+				entry.FromPort = aws.ToInt32(e.PortRange.From)
+				entry.ToPort = aws.ToInt32(e.PortRange.To)
+			}
+
+			if e.IcmpTypeCode != nil {
+				entry.IcmpCode = e.IcmpTypeCode.Code
+				entry.IcmpType = e.IcmpTypeCode.Type
+			}
+
+			if aws.ToBool(e.Egress) {
+				acl.Egress = append(acl.Egress, entry)
+			} else {
+				acl.Ingress = append(acl.Ingress, entry)
+			}
+		}
+
+		nacls[acl.ID] = acl
 	}
+	return
+}
+
+// getVpnGateway returns the virtual private gateway attached to the VPC along
+// with its VPN connections and the customer gateways they terminate on, both
+// keyed by ID
+func (f *Function) getVpnGateway(client AwsEc2Api, vpcId string) (vgw *xfnd.VpnGateway, connections map[string]xfnd.VpnConnection, cgws map[string]xfnd.CustomerGateway, err error) {
+	f.log.Info("Getting VPN gateways", "vpc", vpcId)
+	connections = make(map[string]xfnd.VpnConnection)
+	cgws = make(map[string]xfnd.CustomerGateway)
+
+	var gateways *ec2.DescribeVpnGatewaysOutput
+	{
+		gateways, err = GetVpnGateways(context.Background(), client, &ec2.DescribeVpnGatewaysInput{
+			Filters: []ec2types.Filter{
+				{
+					Name:   aws.String("attachment.vpc-id"),
+					Values: []string{vpcId},
+				},
+				{
+					Name:   aws.String("attachment.state"),
+					Values: []string{string(ec2types.AttachmentStatusAttached)},
+				},
+			},
+		})
+		if err != nil {
+			return
+		}
+
+		// A VPC can only have a single virtual private gateway attached
+		if len(gateways.VpnGateways) == 0 {
+			return
+		}
+
+		var g ec2types.VpnGateway = gateways.VpnGateways[0]
+		vgw = &xfnd.VpnGateway{
+			ID:               *g.VpnGatewayId,
+			AmazonSideAsn:    aws.ToInt64(g.AmazonSideAsn),
+			AvailabilityZone: aws.ToString(g.AvailabilityZone),
+			State:            string(g.State),
+			Type:             string(g.Type),
+		}
+
+		for _, tag := range g.Tags {
+			if *tag.Key == nametag {
+				vgw.Name = *tag.Value
+			}
+		}
+	}
+
+	var vpns *ec2.DescribeVpnConnectionsOutput
+	{
+		vpns, err = GetVpnConnections(context.Background(), client, &ec2.DescribeVpnConnectionsInput{
+			Filters: []ec2types.Filter{
+				{
+					Name:   aws.String("vpn-gateway-id"),
+					Values: []string{vgw.ID},
+				},
+			},
+		})
+		if err != nil {
+			return
+		}
+
+		var cgwIds []string
+		for _, v := range vpns.VpnConnections {
+			if v.State == ec2types.VpnStateDeleted {
+				continue
+			}
+
+			f.log.Info("Processing VPN connection", "vpn", *v.VpnConnectionId)
+			var connection xfnd.VpnConnection = xfnd.VpnConnection{
+				ID:                *v.VpnConnectionId,
+				CustomerGatewayID: aws.ToString(v.CustomerGatewayId),
+				State:             string(v.State),
+				Type:              string(v.Type),
+				VpnGatewayID:      aws.ToString(v.VpnGatewayId),
+				Routes:            make([]string, 0, len(v.Routes)),
+				Tunnels:           make([]xfnd.VpnTunnel, 0, len(v.VgwTelemetry)),
+			}
+
+			for _, tag := range v.Tags {
+				if *tag.Key == nametag {
+					connection.Name = *tag.Value
+				}
+			}
+
+			if v.Options != nil {
+				connection.StaticRoutesOnly = aws.ToBool(v.Options.StaticRoutesOnly)
+			}
+
+			for _, r := range v.Routes {
+				connection.Routes = append(connection.Routes, aws.ToString(r.DestinationCidrBlock))
+			}
+
+			for _, t := range v.VgwTelemetry {
+				connection.Tunnels = append(connection.Tunnels, xfnd.VpnTunnel{
+					OutsideIPAddress: aws.ToString(t.OutsideIpAddress),
+					Status:           string(t.Status),
+					StatusMessage:    aws.ToString(t.StatusMessage),
+				})
+			}
+
+			connections[connection.ID] = connection
+			if connection.CustomerGatewayID != "" {
+				cgwIds = append(cgwIds, connection.CustomerGatewayID)
+			}
+		}
+
+		if len(cgwIds) == 0 {
+			return
+		}
+
+		var customers *ec2.DescribeCustomerGatewaysOutput
+		customers, err = GetCustomerGateways(context.Background(), client, &ec2.DescribeCustomerGatewaysInput{
+			Filters: []ec2types.Filter{
+				{
+					Name:   aws.String("customer-gateway-id"),
+					Values: cgwIds,
+				},
+			},
+		})
+		if err != nil {
+			return
+		}
+
+		for _, c := range customers.CustomerGateways {
+			var cgw xfnd.CustomerGateway = xfnd.CustomerGateway{
+				ID:         *c.CustomerGatewayId,
+				BgpAsn:     aws.ToString(c.BgpAsn),
+				DeviceName: aws.ToString(c.DeviceName),
+				IPAddress:  aws.ToString(c.IpAddress),
+				State:      aws.ToString(c.State),
+				Type:       aws.ToString(c.Type),
+			}
+
+			for _, tag := range c.Tags {
+				if *tag.Key == nametag {
+					cgw.Name = *tag.Value
+				}
+			}
+			cgws[cgw.ID] = cgw
+		}
+	}
+	return
+}
+
+// getDirectConnectGatewayAssociations returns the Direct Connect gateway
+// associations of the VPN gateway and transit gateways discovered for the VPC
+// keyed by association ID
+func (f *Function) getDirectConnectGatewayAssociations(client AwsDirectConnectApi, vpc *xfnd.AwsVpc) (associations map[string]xfnd.DirectConnectGatewayAssociation, err error) {
+	var gatewayIds []string
+	if vpc.VpnGateway != nil {
+		gatewayIds = append(gatewayIds, vpc.VpnGateway.ID)
+	}
+	for _, tgw := range vpc.TransitGateways {
+		gatewayIds = append(gatewayIds, tgw.ID)
+	}
+
+	if len(gatewayIds) == 0 {
+		return
+	}
+
+	associations = make(map[string]xfnd.DirectConnectGatewayAssociation)
+	for _, id := range gatewayIds {
+		f.log.Info("Getting Direct Connect gateway associations", "gateway", id)
+		var output *directconnect.DescribeDirectConnectGatewayAssociationsOutput
+		{
+			output, err = GetDirectConnectGatewayAssociations(context.Background(), client, &directconnect.DescribeDirectConnectGatewayAssociationsInput{
+				AssociatedGatewayId: aws.String(id),
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to describe Direct Connect gateway associations for %s", id)
+			}
+		}
+
+		for _, a := range output.DirectConnectGatewayAssociations {
+			if a.AssociationState == dxtypes.DirectConnectGatewayAssociationStateDisassociated {
+				continue
+			}
 
+			var association xfnd.DirectConnectGatewayAssociation = xfnd.DirectConnectGatewayAssociation{
+				ID:                               aws.ToString(a.AssociationId),
+				AllowedPrefixes:                  make([]string, 0, len(a.AllowedPrefixesToDirectConnectGateway)),
+				DirectConnectGatewayID:           aws.ToString(a.DirectConnectGatewayId),
+				DirectConnectGatewayOwnerAccount: aws.ToString(a.DirectConnectGatewayOwnerAccount),
+				State:                            string(a.AssociationState),
+				StateChangeError:                 aws.ToString(a.StateChangeError),
+			}
+
+			if a.AssociatedGateway != nil {
+				association.AssociatedGatewayID = aws.ToString(a.AssociatedGateway.Id)
+				association.AssociatedGatewayOwnerAccount = aws.ToString(a.AssociatedGateway.OwnerAccount)
+				association.AssociatedGatewayType = string(a.AssociatedGateway.Type)
+			} else {
+				association.AssociatedGatewayID = aws.ToString(a.VirtualGatewayId)
+				association.AssociatedGatewayOwnerAccount = aws.ToString(a.VirtualGatewayOwnerAccount)
+				association.AssociatedGatewayType = string(dxtypes.GatewayTypeVirtualPrivateGateway)
+			}
+
+			for _, p := range a.AllowedPrefixesToDirectConnectGateway {
+				association.AllowedPrefixes = append(association.AllowedPrefixes, aws.ToString(p.Cidr))
+			}
+
+			associations[association.ID] = association
+		}
+	}
+	return
+}
+
+// getPrivateHostedZones returns the private hosted zones associated with the
+// VPC keyed by hosted zone ID
+func (f *Function) getPrivateHostedZones(client AwsRoute53Api, vpcId, region string) (zones map[string]xfnd.PrivateHostedZone, err error) {
+	f.log.Info("Getting private hosted zones", "vpc", vpcId)
+	var output *route53.ListHostedZonesByVPCOutput
+	{
+		output, err = GetHostedZonesByVpc(context.Background(), client, &route53.ListHostedZonesByVPCInput{
+			VPCId:     aws.String(vpcId),
+			VPCRegion: r53types.VPCRegion(region),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list hosted zones for %s", vpcId)
+		}
+	}
+
+	zones = make(map[string]xfnd.PrivateHostedZone)
+	for _, z := range output.HostedZoneSummaries {
+		var zone xfnd.PrivateHostedZone = xfnd.PrivateHostedZone{
+			ID:   strings.TrimPrefix(aws.ToString(z.HostedZoneId), "/hostedzone/"),
+			Name: aws.ToString(z.Name),
+		}
+
+		if z.Owner != nil {
+			zone.OwningAccount = aws.ToString(z.Owner.OwningAccount)
+			zone.OwningService = aws.ToString(z.Owner.OwningService)
+		}
+		zones[zone.ID] = zone
+	}
+	return
+}
+
+// getResolverRules returns the Resolver rules associated with the VPC keyed by
+// rule ID
+func (f *Function) getResolverRules(client AwsRoute53ResolverApi, vpcId string) (rules map[string]xfnd.ResolverRule, err error) {
+	f.log.Info("Getting Resolver rule associations", "vpc", vpcId)
+	var associations *route53resolver.ListResolverRuleAssociationsOutput
+	{
+		associations, err = GetResolverRuleAssociations(context.Background(), client, &route53resolver.ListResolverRuleAssociationsInput{
+			Filters: []r53rtypes.Filter{
+				{
+					Name:   aws.String("VPCId"),
+					Values: []string{vpcId},
+				},
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list Resolver rule associations for %s", vpcId)
+		}
+	}
+
+	rules = make(map[string]xfnd.ResolverRule)
+
+	// Each rule is only fetched once, however many associations refer to it
+	var fetched map[string]*r53rtypes.ResolverRule = make(map[string]*r53rtypes.ResolverRule)
+	for _, a := range associations.ResolverRuleAssociations {
+		if a.Status == r53rtypes.ResolverRuleAssociationStatusDeleting || a.Status == r53rtypes.ResolverRuleAssociationStatusFailed {
+			continue
+		}
+
+		var (
+			id string = aws.ToString(a.ResolverRuleId)
+			r  *r53rtypes.ResolverRule
+			ok bool
+		)
+		if r, ok = fetched[id]; !ok {
+			var output *route53resolver.GetResolverRuleOutput
+			output, err = GetResolverRule(context.Background(), client, &route53resolver.GetResolverRuleInput{
+				ResolverRuleId: a.ResolverRuleId,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get Resolver rule %s", id)
+			}
+			r = output.ResolverRule
+			fetched[id] = r
+		}
+
+		var rule xfnd.ResolverRule = xfnd.ResolverRule{
+			ID:                aws.ToString(a.ResolverRuleId),
+			AssociationID:     aws.ToString(a.Id),
+			AssociationStatus: string(a.Status),
+			TargetIps:         make([]xfnd.ResolverTargetAddress, 0),
+		}
+
+		if r != nil {
+			rule.ARN = aws.ToString(r.Arn)
+			rule.DomainName = aws.ToString(r.DomainName)
+			rule.Name = aws.ToString(r.Name)
+			rule.OwnerID = aws.ToString(r.OwnerId)
+			rule.ResolverEndpointID = aws.ToString(r.ResolverEndpointId)
+			rule.RuleType = string(r.RuleType)
+			rule.ShareStatus = string(r.ShareStatus)
+			rule.Status = string(r.Status)
+
+			for _, t := range r.TargetIps {
+				rule.TargetIps = append(rule.TargetIps, xfnd.ResolverTargetAddress{
+					IP:       aws.ToString(t.Ip),
+					Ipv6:     aws.ToString(t.Ipv6),
+					Port:     aws.ToInt32(t.Port),
+					Protocol: string(t.Protocol),
+				})
+			}
+		}
+		rules[rule.ID] = rule
+	}
+	return
+}
+
+// getDhcpOptions returns the DHCP options set with the given ID. VPCs using
+// the `default` placeholder have no options set and return nil
+func (f *Function) getDhcpOptions(client AwsEc2Api, id string) (options *xfnd.DhcpOptions, err error) {
+	if id == "" || id == "default" {
+		return
+	}
+
+	f.log.Info("Getting DHCP options", "dhcpOptions", id)
+	var output *ec2.DescribeDhcpOptionsOutput
+	{
+		output, err = GetDhcpOptions(context.Background(), client, &ec2.DescribeDhcpOptionsInput{
+			DhcpOptionsIds: []string{id},
+		})
+		if err != nil {
+			return
+		}
+
+		if len(output.DhcpOptions) == 0 {
+			err = errors.Errorf("DHCP options %s not found", id)
+			return
+		}
+	}
+
+	var d ec2types.DhcpOptions = output.DhcpOptions[0]
+	options = &xfnd.DhcpOptions{
+		ID:      aws.ToString(d.DhcpOptionsId),
+		OwnerID: aws.ToString(d.OwnerId),
+	}
+
+	for _, tag := range d.Tags {
+		if *tag.Key == nametag {
+			options.Name = *tag.Value
+		}
+	}
+
+	for _, c := range d.DhcpConfigurations {
+		var values []string = make([]string, 0, len(c.Values))
+		for _, v := range c.Values {
+			values = append(values, aws.ToString(v.Value))
+		}
+
+		switch aws.ToString(c.Key) {
+		case "domain-name":
+			options.DomainName = strings.Join(values, " ")
+		case "domain-name-servers":
+			options.DomainNameServers = values
+		case "ntp-servers":
+			options.NtpServers = values
+		case "netbios-name-servers":
+			options.NetbiosNameServers = values
+		case "netbios-node-type":
+			if len(values) > 0 {
+				options.NetbiosNodeType = values[0]
+			}
+		case "ipv6-address-preferred-lease-time":
+			if len(values) > 0 {
+				options.Ipv6AddressPreferredLeaseTime = values[0]
+			}
+		}
+	}
+
+	for _, server := range options.DomainNameServers {
+		if server != "AmazonProvidedDNS" {
+			options.CustomDomainNameServers = true
+		}
+	}
+	return
+}
+
+// getSubnetCidrReservations returns the IPv4 and IPv6 CIDR reservations
+// defined in the subnet
+func (f *Function) getSubnetCidrReservations(client AwsEc2Api, subnetId string) (reservations []xfnd.SubnetCidrReservation, err error) {
+	var output *ec2.GetSubnetCidrReservationsOutput
+	{
+		output, err = GetSubnetCidrReservations(context.Background(), client, &ec2.GetSubnetCidrReservationsInput{
+			SubnetId: aws.String(subnetId),
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	for _, r := range append(output.SubnetIpv4CidrReservations, output.SubnetIpv6CidrReservations...) {
+		reservations = append(reservations, xfnd.SubnetCidrReservation{
+			ID:              aws.ToString(r.SubnetCidrReservationId),
+			Cidr:            aws.ToString(r.Cidr),
+			Description:     aws.ToString(r.Description),
+			ReservationType: string(r.ReservationType),
+		})
+	}
+	return
+}
+
+// getAvailabilityZones returns the zones of the region the account is opted in
+// to. Zones that do not require opting in are always included
+func (f *Function) getAvailabilityZones(client AwsEc2Api) (zones []xfnd.AvailabilityZone, err error) {
+	f.log.Info("Getting availability zones")
+	var output *ec2.DescribeAvailabilityZonesOutput
+	{
+		output, err = GetAvailabilityZones(context.Background(), client, &ec2.DescribeAvailabilityZonesInput{
+			AllAvailabilityZones: aws.Bool(true),
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	zones = make([]xfnd.AvailabilityZone, 0, len(output.AvailabilityZones))
+	for _, az := range output.AvailabilityZones {
+		if az.OptInStatus == ec2types.AvailabilityZoneOptInStatusNotOptedIn {
+			continue
+		}
+
+		zones = append(zones, xfnd.AvailabilityZone{
+			ID:                 aws.ToString(az.ZoneId),
+			Name:               aws.ToString(az.ZoneName),
+			GroupName:          aws.ToString(az.GroupName),
+			NetworkBorderGroup: aws.ToString(az.NetworkBorderGroup),
+			OptInStatus:        string(az.OptInStatus),
+			ParentZoneID:       aws.ToString(az.ParentZoneId),
+			ParentZoneName:     aws.ToString(az.ParentZoneName),
+			State:              string(az.State),
+			Type:               aws.ToString(az.ZoneType),
+		})
+	}
 	return
 }
@@ -0,0 +1,1294 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect"
+	dxtypes "github.com/aws/aws-sdk-go-v2/service/directconnect/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53resolver"
+	r53rtypes "github.com/aws/aws-sdk-go-v2/service/route53resolver/types"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	xfnd "github.com/giantswarm/crossplane-fn-network-discovery/pkg/composite/v1beta1"
+	inp "github.com/giantswarm/crossplane-fn-network-discovery/pkg/input/v1beta1"
+)
+
+// fakePages returns the page of items starting at the offset held in token,
+// along with the token of the next page if there is one
+func fakePages[T any](items []T, size int, token *string) (page []T, next *string) {
+	var start int
+	if t := aws.ToString(token); t != "" {
+		start, _ = strconv.Atoi(t)
+	}
+
+	if size <= 0 {
+		size = len(items)
+	}
+
+	var end int = min(start+size, len(items))
+	if end < len(items) {
+		next = aws.String(strconv.Itoa(end))
+	}
+	return items[start:end], next
+}
+
+// fakeItems returns the given items or, when none are set, n zero values
+func fakeItems[T any](items []T, n int) []T {
+	if items != nil {
+		return items
+	}
+	return make([]T, n)
+}
+
+// fakeEc2 returns `items` results for every paginated call, split into pages
+// of `pageSize`, unless the result is set explicitly. Calls which are not
+// implemented panic on the nil interface.
+type fakeEc2 struct {
+	AwsEc2Api
+	items    int
+	pageSize int
+	calls    int
+
+	// When set, returned instead of `items` zero values
+	vpcs         []ec2types.Vpc
+	subnets      []ec2types.Subnet
+	routeTables  []ec2types.RouteTable
+	vpcEndpoints []ec2types.VpcEndpoint
+
+	// Returned from SearchTransitGatewayRoutes
+	tgwRoutes *ec2.SearchTransitGatewayRoutesOutput
+}
+
+func (f *fakeEc2) DescribeAvailabilityZones(_ context.Context, _ *ec2.DescribeAvailabilityZonesInput, _ ...func(*ec2.Options)) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	f.calls++
+	return &ec2.DescribeAvailabilityZonesOutput{}, nil
+}
+
+func (f *fakeEc2) DescribeVpnGateways(_ context.Context, _ *ec2.DescribeVpnGatewaysInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpnGatewaysOutput, error) {
+	f.calls++
+	return &ec2.DescribeVpnGatewaysOutput{}, nil
+}
+
+func (f *fakeEc2) SearchTransitGatewayRoutes(_ context.Context, _ *ec2.SearchTransitGatewayRoutesInput, _ ...func(*ec2.Options)) (*ec2.SearchTransitGatewayRoutesOutput, error) {
+	f.calls++
+	return f.tgwRoutes, nil
+}
+
+func (f *fakeEc2) DescribeVpcs(_ context.Context, params *ec2.DescribeVpcsInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
+	f.calls++
+	page, next := fakePages(fakeItems(f.vpcs, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeVpcsOutput{Vpcs: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeSubnets(_ context.Context, params *ec2.DescribeSubnetsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	f.calls++
+	page, next := fakePages(fakeItems(f.subnets, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeSubnetsOutput{Subnets: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeSecurityGroups(_ context.Context, params *ec2.DescribeSecurityGroupsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.SecurityGroup, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeRouteTables(_ context.Context, params *ec2.DescribeRouteTablesInput, _ ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	f.calls++
+	page, next := fakePages(fakeItems(f.routeTables, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeRouteTablesOutput{RouteTables: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeNatGateways(_ context.Context, params *ec2.DescribeNatGatewaysInput, _ ...func(*ec2.Options)) (*ec2.DescribeNatGatewaysOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.NatGateway, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeNatGatewaysOutput{NatGateways: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeTransitGateways(_ context.Context, params *ec2.DescribeTransitGatewaysInput, _ ...func(*ec2.Options)) (*ec2.DescribeTransitGatewaysOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.TransitGateway, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeTransitGatewaysOutput{TransitGateways: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeTransitGatewayAttachments(_ context.Context, params *ec2.DescribeTransitGatewayAttachmentsInput, _ ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayAttachmentsOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.TransitGatewayAttachment, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeTransitGatewayAttachmentsOutput{TransitGatewayAttachments: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeTransitGatewayRouteTables(_ context.Context, params *ec2.DescribeTransitGatewayRouteTablesInput, _ ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayRouteTablesOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.TransitGatewayRouteTable, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeTransitGatewayRouteTablesOutput{TransitGatewayRouteTables: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeVpcPeeringConnections(_ context.Context, params *ec2.DescribeVpcPeeringConnectionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpcPeeringConnectionsOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.VpcPeeringConnection, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeVpcPeeringConnectionsOutput{VpcPeeringConnections: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeVpcEndpoints(_ context.Context, params *ec2.DescribeVpcEndpointsInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpcEndpointsOutput, error) {
+	f.calls++
+	page, next := fakePages(fakeItems(f.vpcEndpoints, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeVpcEndpointsOutput{VpcEndpoints: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeNetworkAcls(_ context.Context, params *ec2.DescribeNetworkAclsInput, _ ...func(*ec2.Options)) (*ec2.DescribeNetworkAclsOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.NetworkAcl, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeNetworkAclsOutput{NetworkAcls: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) GetTransitGatewayRouteTableAssociations(_ context.Context, params *ec2.GetTransitGatewayRouteTableAssociationsInput, _ ...func(*ec2.Options)) (*ec2.GetTransitGatewayRouteTableAssociationsOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.TransitGatewayRouteTableAssociation, f.items), f.pageSize, params.NextToken)
+	return &ec2.GetTransitGatewayRouteTableAssociationsOutput{Associations: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) GetTransitGatewayRouteTablePropagations(_ context.Context, params *ec2.GetTransitGatewayRouteTablePropagationsInput, _ ...func(*ec2.Options)) (*ec2.GetTransitGatewayRouteTablePropagationsOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.TransitGatewayRouteTablePropagation, f.items), f.pageSize, params.NextToken)
+	return &ec2.GetTransitGatewayRouteTablePropagationsOutput{TransitGatewayRouteTablePropagations: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeTransitGatewayPeeringAttachments(_ context.Context, params *ec2.DescribeTransitGatewayPeeringAttachmentsInput, _ ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayPeeringAttachmentsOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.TransitGatewayPeeringAttachment, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeTransitGatewayPeeringAttachmentsOutput{TransitGatewayPeeringAttachments: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) DescribeDhcpOptions(_ context.Context, params *ec2.DescribeDhcpOptionsInput, _ ...func(*ec2.Options)) (*ec2.DescribeDhcpOptionsOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.DhcpOptions, f.items), f.pageSize, params.NextToken)
+	return &ec2.DescribeDhcpOptionsOutput{DhcpOptions: page, NextToken: next}, nil
+}
+
+func (f *fakeEc2) GetSubnetCidrReservations(_ context.Context, params *ec2.GetSubnetCidrReservationsInput, _ ...func(*ec2.Options)) (*ec2.GetSubnetCidrReservationsOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]ec2types.SubnetCidrReservation, f.items), f.pageSize, params.NextToken)
+	return &ec2.GetSubnetCidrReservationsOutput{SubnetIpv4CidrReservations: page, NextToken: next}, nil
+}
+
+// fakeDirectConnect returns the associations of the requested gateway split
+// into pages of `pageSize`
+type fakeDirectConnect struct {
+	associations map[string][]dxtypes.DirectConnectGatewayAssociation
+	pageSize     int
+	calls        int
+}
+
+func (f *fakeDirectConnect) DescribeDirectConnectGatewayAssociations(_ context.Context, params *directconnect.DescribeDirectConnectGatewayAssociationsInput, _ ...func(*directconnect.Options)) (*directconnect.DescribeDirectConnectGatewayAssociationsOutput, error) {
+	f.calls++
+	page, next := fakePages(f.associations[aws.ToString(params.AssociatedGatewayId)], f.pageSize, params.NextToken)
+	return &directconnect.DescribeDirectConnectGatewayAssociationsOutput{DirectConnectGatewayAssociations: page, NextToken: next}, nil
+}
+
+type fakeRoute53 struct {
+	items    int
+	pageSize int
+	calls    int
+}
+
+func (f *fakeRoute53) ListHostedZonesByVPC(_ context.Context, params *route53.ListHostedZonesByVPCInput, _ ...func(*route53.Options)) (*route53.ListHostedZonesByVPCOutput, error) {
+	f.calls++
+	page, next := fakePages(make([]r53types.HostedZoneSummary, f.items), f.pageSize, params.NextToken)
+	return &route53.ListHostedZonesByVPCOutput{HostedZoneSummaries: page, NextToken: next}, nil
+}
+
+type fakeRoute53Resolver struct {
+	AwsRoute53ResolverApi
+	items    int
+	pageSize int
+	calls    int
+
+	// When set, returned instead of `items` zero value associations
+	associations []r53rtypes.ResolverRuleAssociation
+	ruleCalls    map[string]int
+}
+
+func (f *fakeRoute53Resolver) GetResolverRule(_ context.Context, params *route53resolver.GetResolverRuleInput, _ ...func(*route53resolver.Options)) (*route53resolver.GetResolverRuleOutput, error) {
+	f.ruleCalls[aws.ToString(params.ResolverRuleId)]++
+	return &route53resolver.GetResolverRuleOutput{ResolverRule: &r53rtypes.ResolverRule{
+		Id:         params.ResolverRuleId,
+		DomainName: aws.String(aws.ToString(params.ResolverRuleId) + ".example.com"),
+		RuleType:   r53rtypes.RuleTypeOptionForward,
+	}}, nil
+}
+
+func (f *fakeRoute53Resolver) ListResolverRuleAssociations(_ context.Context, params *route53resolver.ListResolverRuleAssociationsInput, _ ...func(*route53resolver.Options)) (*route53resolver.ListResolverRuleAssociationsOutput, error) {
+	f.calls++
+	var associations []r53rtypes.ResolverRuleAssociation = f.associations
+	if associations == nil {
+		associations = make([]r53rtypes.ResolverRuleAssociation, f.items)
+	}
+	page, next := fakePages(associations, f.pageSize, params.NextToken)
+	return &route53resolver.ListResolverRuleAssociationsOutput{ResolverRuleAssociations: page, NextToken: next}, nil
+}
+
+func TestPaginatedHelpers(t *testing.T) {
+	const (
+		items    = 7
+		pageSize = 3
+		pages    = 3
+	)
+
+	var c context.Context = context.Background()
+	tests := map[string]func(api *fakeEc2) (int, error){
+		"GetVpc": func(api *fakeEc2) (int, error) {
+			o, err := GetVpc(c, api, &ec2.DescribeVpcsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.Vpcs), nil
+		},
+		"GetSubnets": func(api *fakeEc2) (int, error) {
+			o, err := GetSubnets(c, api, &ec2.DescribeSubnetsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.Subnets), nil
+		},
+		"GetSecurityGroups": func(api *fakeEc2) (int, error) {
+			o, err := GetSecurityGroups(c, api, &ec2.DescribeSecurityGroupsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.SecurityGroups), nil
+		},
+		"GetRouteTables": func(api *fakeEc2) (int, error) {
+			o, err := GetRouteTables(c, api, &ec2.DescribeRouteTablesInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.RouteTables), nil
+		},
+		"GetNatGateways": func(api *fakeEc2) (int, error) {
+			o, err := GetNatGateways(c, api, &ec2.DescribeNatGatewaysInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.NatGateways), nil
+		},
+		"GetTransitGateways": func(api *fakeEc2) (int, error) {
+			o, err := GetTransitGateways(c, api, &ec2.DescribeTransitGatewaysInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.TransitGateways), nil
+		},
+		"GetTransitGatewayAttachments": func(api *fakeEc2) (int, error) {
+			o, err := GetTransitGatewayAttachments(c, api, &ec2.DescribeTransitGatewayAttachmentsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.TransitGatewayAttachments), nil
+		},
+		"GetTransitGatewayRouteTables": func(api *fakeEc2) (int, error) {
+			o, err := GetTransitGatewayRouteTables(c, api, &ec2.DescribeTransitGatewayRouteTablesInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.TransitGatewayRouteTables), nil
+		},
+		"GetVpcPeeringConnections": func(api *fakeEc2) (int, error) {
+			o, err := GetVpcPeeringConnections(c, api, &ec2.DescribeVpcPeeringConnectionsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.VpcPeeringConnections), nil
+		},
+		"GetVpcEndpoints": func(api *fakeEc2) (int, error) {
+			o, err := GetVpcEndpoints(c, api, &ec2.DescribeVpcEndpointsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.VpcEndpoints), nil
+		},
+		"GetNetworkAcls": func(api *fakeEc2) (int, error) {
+			o, err := GetNetworkAcls(c, api, &ec2.DescribeNetworkAclsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.NetworkAcls), nil
+		},
+		"GetTransitGatewayRouteTableAssociations": func(api *fakeEc2) (int, error) {
+			o, err := GetTransitGatewayRouteTableAssociations(c, api, &ec2.GetTransitGatewayRouteTableAssociationsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.Associations), nil
+		},
+		"GetTransitGatewayRouteTablePropagations": func(api *fakeEc2) (int, error) {
+			o, err := GetTransitGatewayRouteTablePropagations(c, api, &ec2.GetTransitGatewayRouteTablePropagationsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.TransitGatewayRouteTablePropagations), nil
+		},
+		"GetTransitGatewayPeeringAttachments": func(api *fakeEc2) (int, error) {
+			o, err := GetTransitGatewayPeeringAttachments(c, api, &ec2.DescribeTransitGatewayPeeringAttachmentsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.TransitGatewayPeeringAttachments), nil
+		},
+		"GetDhcpOptions": func(api *fakeEc2) (int, error) {
+			o, err := GetDhcpOptions(c, api, &ec2.DescribeDhcpOptionsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.DhcpOptions), nil
+		},
+		"GetSubnetCidrReservations": func(api *fakeEc2) (int, error) {
+			o, err := GetSubnetCidrReservations(c, api, &ec2.GetSubnetCidrReservationsInput{})
+			if err != nil {
+				return 0, err
+			}
+			return len(o.SubnetIpv4CidrReservations), nil
+		},
+	}
+
+	for name, get := range tests {
+		t.Run(name, func(t *testing.T) {
+			var api *fakeEc2 = &fakeEc2{items: items, pageSize: pageSize}
+			got, err := get(api)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != items {
+				t.Errorf("got %d items, want %d", got, items)
+			}
+
+			if api.calls != pages {
+				t.Errorf("got %d calls, want %d", api.calls, pages)
+			}
+		})
+	}
+}
+
+func TestGetDirectConnectGatewayAssociationsPaginates(t *testing.T) {
+	var api *fakeDirectConnect = &fakeDirectConnect{
+		associations: map[string][]dxtypes.DirectConnectGatewayAssociation{
+			"vgw-1": make([]dxtypes.DirectConnectGatewayAssociation, 5),
+		},
+		pageSize: 2,
+	}
+
+	o, err := GetDirectConnectGatewayAssociations(context.Background(), api, &directconnect.DescribeDirectConnectGatewayAssociationsInput{
+		AssociatedGatewayId: aws.String("vgw-1"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(o.DirectConnectGatewayAssociations) != 5 || api.calls != 3 {
+		t.Errorf("got %d associations in %d calls, want 5 in 3", len(o.DirectConnectGatewayAssociations), api.calls)
+	}
+}
+
+func TestGetHostedZonesByVpcPaginates(t *testing.T) {
+	var api *fakeRoute53 = &fakeRoute53{items: 4, pageSize: 2}
+
+	o, err := GetHostedZonesByVpc(context.Background(), api, &route53.ListHostedZonesByVPCInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(o.HostedZoneSummaries) != 4 || api.calls != 2 {
+		t.Errorf("got %d hosted zones in %d calls, want 4 in 2", len(o.HostedZoneSummaries), api.calls)
+	}
+}
+
+func TestGetResolverRuleAssociationsPaginates(t *testing.T) {
+	var api *fakeRoute53Resolver = &fakeRoute53Resolver{items: 3, pageSize: 1}
+
+	o, err := GetResolverRuleAssociations(context.Background(), api, &route53resolver.ListResolverRuleAssociationsInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(o.ResolverRuleAssociations) != 3 || api.calls != 3 {
+		t.Errorf("got %d associations in %d calls, want 3 in 3", len(o.ResolverRuleAssociations), api.calls)
+	}
+}
+
+func TestGetVpcEndpointsKeepsEveryEndpointOfAService(t *testing.T) {
+	var (
+		s3  string   = "com.amazonaws.eu-west-1.s3"
+		ecr string   = "com.amazonaws.eu-west-1.ecr.api"
+		api *fakeEc2 = &fakeEc2{
+			pageSize: 2,
+			vpcEndpoints: []ec2types.VpcEndpoint{
+				{VpcEndpointId: aws.String("vpce-3"), ServiceName: aws.String(s3), VpcEndpointType: ec2types.VpcEndpointTypeInterface},
+				{VpcEndpointId: aws.String("vpce-1"), ServiceName: aws.String(s3), VpcEndpointType: ec2types.VpcEndpointTypeGateway},
+				{VpcEndpointId: aws.String("vpce-2"), ServiceName: aws.String(ecr), VpcEndpointType: ec2types.VpcEndpointTypeInterface},
+			},
+		}
+		f *Function = &Function{log: logging.NewNopLogger()}
+	)
+
+	endpoints, err := f.getVpcEndpoints(api, "vpc-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(endpoints[s3]) != 2 || endpoints[s3][0].ID != "vpce-1" || endpoints[s3][1].ID != "vpce-3" {
+		t.Errorf("got %+v for %s, want vpce-1 and vpce-3", endpoints[s3], s3)
+	}
+
+	if endpoints[s3][0].Type != string(ec2types.VpcEndpointTypeGateway) || endpoints[s3][1].Type != string(ec2types.VpcEndpointTypeInterface) {
+		t.Errorf("got types %s and %s, want Gateway and Interface", endpoints[s3][0].Type, endpoints[s3][1].Type)
+	}
+
+	if len(endpoints[ecr]) != 1 {
+		t.Errorf("got %d endpoints for %s, want 1", len(endpoints[ecr]), ecr)
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		name     string
+		want     bool
+	}{
+		{nil, "anything", true},
+		{[]string{"team*"}, "team/app", true},
+		{[]string{"*/app"}, "team/a/app", true},
+		{[]string{"eks-cluster-sg-*"}, "eks-cluster-sg-abc", true},
+		{[]string{"eks-cluster-sg-*"}, "other-eks-cluster-sg-abc", false},
+		{[]string{"sg-?"}, "sg-1", true},
+		{[]string{"sg-?"}, "sg-12", false},
+		{[]string{"web", "db-*"}, "db-primary", true},
+		{[]string{"a.b"}, "axb", false},
+		{[]string{"[ab]"}, "a", false},
+		{[]string{"[ab]"}, "[ab]", true},
+		{[]string{`literal\*`}, "literal*", true},
+		{[]string{`literal\*`}, "literalx", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesAny(globRegexps(tt.patterns), tt.name); got != tt.want {
+			t.Errorf("matchesAny(%q, %q) = %v, want %v", tt.patterns, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAwsPartition(t *testing.T) {
+	tests := map[string]string{
+		"us-east-1":       "aws",
+		"eu-west-1":       "aws",
+		"cn-north-1":      "aws-cn",
+		"cn-northwest-1":  "aws-cn",
+		"us-gov-west-1":   "aws-us-gov",
+		"us-iso-east-1":   "aws-iso",
+		"us-isob-east-1":  "aws-iso-b",
+		"us-isof-south-1": "aws-iso-f",
+		"eu-isoe-west-1":  "aws-iso-e",
+	}
+
+	for region, want := range tests {
+		if got := awsPartition(region); got != want {
+			t.Errorf("awsPartition(%q) = %q, want %q", region, got, want)
+		}
+	}
+}
+
+func TestAwsArn(t *testing.T) {
+	const (
+		account  = "123456789012"
+		resource = "vpc-peering-connection/pcx-1"
+	)
+
+	tests := []struct {
+		name     string
+		region   string
+		account  string
+		resource string
+		want     string
+		wantErr  bool
+	}{
+		{name: "commercial", region: "us-east-1", account: account, resource: resource, want: "arn:aws:ec2:us-east-1:123456789012:vpc-peering-connection/pcx-1"},
+		{name: "china", region: "cn-north-1", account: account, resource: resource, want: "arn:aws-cn:ec2:cn-north-1:123456789012:vpc-peering-connection/pcx-1"},
+		{name: "govcloud", region: "us-gov-west-1", account: account, resource: resource, want: "arn:aws-us-gov:ec2:us-gov-west-1:123456789012:vpc-peering-connection/pcx-1"},
+		{name: "iso", region: "us-iso-east-1", account: account, resource: resource, want: "arn:aws-iso:ec2:us-iso-east-1:123456789012:vpc-peering-connection/pcx-1"},
+		{name: "iso-b", region: "us-isob-east-1", account: account, resource: resource, want: "arn:aws-iso-b:ec2:us-isob-east-1:123456789012:vpc-peering-connection/pcx-1"},
+		{name: "iso-f", region: "us-isof-south-1", account: account, resource: resource, want: "arn:aws-iso-f:ec2:us-isof-south-1:123456789012:vpc-peering-connection/pcx-1"},
+		{name: "iso-e", region: "eu-isoe-west-1", account: account, resource: resource, want: "arn:aws-iso-e:ec2:eu-isoe-west-1:123456789012:vpc-peering-connection/pcx-1"},
+		{name: "empty region", account: account, resource: resource, wantErr: true},
+		{name: "empty account", region: "us-east-1", resource: resource, wantErr: true},
+		{name: "empty resource", region: "us-east-1", account: account, wantErr: true},
+		{name: "invalid region", region: "east", account: account, resource: resource, wantErr: true},
+		{name: "invalid account", region: "us-east-1", account: "1234", resource: resource, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := awsArn("ec2", tt.region, tt.account, tt.resource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTransitGatewayRouteTableDetails(t *testing.T) {
+	var (
+		api *fakeEc2 = &fakeEc2{
+			pageSize: 1,
+			tgwRoutes: &ec2.SearchTransitGatewayRoutesOutput{
+				AdditionalRoutesAvailable: aws.Bool(true),
+				Routes: []ec2types.TransitGatewayRoute{
+					{DestinationCidrBlock: aws.String("10.0.0.0/16"), Type: ec2types.TransitGatewayRouteTypeStatic},
+					{PrefixListId: aws.String("pl-1"), Type: ec2types.TransitGatewayRouteTypePropagated},
+					{Type: ec2types.TransitGatewayRouteTypeStatic, State: ec2types.TransitGatewayRouteStateBlackhole},
+					{Type: ec2types.TransitGatewayRouteTypeStatic, State: ec2types.TransitGatewayRouteStateActive},
+				},
+			},
+		}
+		f     *Function                      = &Function{log: logging.NewNopLogger()}
+		table *xfnd.TransitGatewayRouteTable = &xfnd.TransitGatewayRouteTable{ID: "tgw-rtb-1"}
+	)
+
+	if err := f.getTransitGatewayRouteTableDetails(api, table); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !table.RoutesIncomplete {
+		t.Error("expected the route table to be flagged as incomplete")
+	}
+
+	for _, key := range []string{"10.0.0.0/16", "pl-1", "route-2", "route-3"} {
+		if _, ok := table.Routes[key]; !ok {
+			t.Errorf("missing route %q in %v", key, table.Routes)
+		}
+	}
+
+	if len(table.Routes) != 4 {
+		t.Errorf("got %d routes, want 4", len(table.Routes))
+	}
+}
+
+func TestGetDirectConnectGatewayAssociations(t *testing.T) {
+	var association = func(id, gateway string, gatewayType dxtypes.GatewayType, state dxtypes.DirectConnectGatewayAssociationState, prefixes ...string) dxtypes.DirectConnectGatewayAssociation {
+		var a dxtypes.DirectConnectGatewayAssociation = dxtypes.DirectConnectGatewayAssociation{
+			AssociationId:          aws.String(id),
+			AssociationState:       state,
+			DirectConnectGatewayId: aws.String("dxgw-1"),
+			AssociatedGateway: &dxtypes.AssociatedGateway{
+				Id:   aws.String(gateway),
+				Type: gatewayType,
+			},
+		}
+		for _, p := range prefixes {
+			a.AllowedPrefixesToDirectConnectGateway = append(a.AllowedPrefixesToDirectConnectGateway, dxtypes.RouteFilterPrefix{Cidr: aws.String(p)})
+		}
+		return a
+	}
+
+	var (
+		vgw     dxtypes.GatewayType                          = dxtypes.GatewayTypeVirtualPrivateGateway
+		tgw     dxtypes.GatewayType                          = dxtypes.GatewayTypeTransitGateway
+		active  dxtypes.DirectConnectGatewayAssociationState = dxtypes.DirectConnectGatewayAssociationStateAssociated
+		removed dxtypes.DirectConnectGatewayAssociationState = dxtypes.DirectConnectGatewayAssociationStateDisassociated
+	)
+
+	tests := []struct {
+		name         string
+		vpc          xfnd.AwsVpc
+		associations map[string][]dxtypes.DirectConnectGatewayAssociation
+		want         map[string]xfnd.DirectConnectGatewayAssociation
+		wantCalls    int
+	}{
+		{
+			name: "no gateways",
+			vpc:  xfnd.AwsVpc{},
+		},
+		{
+			name: "vgw only",
+			vpc:  xfnd.AwsVpc{VpnGateway: &xfnd.VpnGateway{ID: "vgw-1"}},
+			associations: map[string][]dxtypes.DirectConnectGatewayAssociation{
+				"vgw-1": {association("a-1", "vgw-1", vgw, active, "10.0.0.0/16")},
+			},
+			want: map[string]xfnd.DirectConnectGatewayAssociation{
+				"a-1": {ID: "a-1", AssociatedGatewayID: "vgw-1", AssociatedGatewayType: string(vgw), DirectConnectGatewayID: "dxgw-1", State: string(active), AllowedPrefixes: []string{"10.0.0.0/16"}},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "tgw only",
+			vpc: xfnd.AwsVpc{TransitGateways: map[string]xfnd.TransitGateway{
+				"core": {ID: "tgw-1"},
+			}},
+			associations: map[string][]dxtypes.DirectConnectGatewayAssociation{
+				"tgw-1": {association("a-2", "tgw-1", tgw, active, "10.0.0.0/8", "172.16.0.0/12")},
+			},
+			want: map[string]xfnd.DirectConnectGatewayAssociation{
+				"a-2": {ID: "a-2", AssociatedGatewayID: "tgw-1", AssociatedGatewayType: string(tgw), DirectConnectGatewayID: "dxgw-1", State: string(active), AllowedPrefixes: []string{"10.0.0.0/8", "172.16.0.0/12"}},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "paging and disassociated",
+			vpc:  xfnd.AwsVpc{VpnGateway: &xfnd.VpnGateway{ID: "vgw-1"}},
+			associations: map[string][]dxtypes.DirectConnectGatewayAssociation{
+				"vgw-1": {
+					association("a-1", "vgw-1", vgw, active),
+					association("a-2", "vgw-1", vgw, removed),
+					association("a-3", "vgw-1", vgw, active),
+				},
+			},
+			want: map[string]xfnd.DirectConnectGatewayAssociation{
+				"a-1": {ID: "a-1", AssociatedGatewayID: "vgw-1", AssociatedGatewayType: string(vgw), DirectConnectGatewayID: "dxgw-1", State: string(active), AllowedPrefixes: []string{}},
+				"a-3": {ID: "a-3", AssociatedGatewayID: "vgw-1", AssociatedGatewayType: string(vgw), DirectConnectGatewayID: "dxgw-1", State: string(active), AllowedPrefixes: []string{}},
+			},
+			wantCalls: 3,
+		},
+		{
+			name: "virtual gateway fallback",
+			vpc:  xfnd.AwsVpc{VpnGateway: &xfnd.VpnGateway{ID: "vgw-1"}},
+			associations: map[string][]dxtypes.DirectConnectGatewayAssociation{
+				"vgw-1": {{
+					AssociationId:              aws.String("a-4"),
+					AssociationState:           active,
+					DirectConnectGatewayId:     aws.String("dxgw-1"),
+					VirtualGatewayId:           aws.String("vgw-1"),
+					VirtualGatewayOwnerAccount: aws.String("123456789012"),
+				}},
+			},
+			want: map[string]xfnd.DirectConnectGatewayAssociation{
+				"a-4": {ID: "a-4", AssociatedGatewayID: "vgw-1", AssociatedGatewayOwnerAccount: "123456789012", AssociatedGatewayType: string(vgw), DirectConnectGatewayID: "dxgw-1", State: string(active), AllowedPrefixes: []string{}},
+			},
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				api *fakeDirectConnect = &fakeDirectConnect{associations: tt.associations, pageSize: 1}
+				f   *Function          = &Function{log: logging.NewNopLogger()}
+			)
+
+			got, err := f.getDirectConnectGatewayAssociations(api, &tt.vpc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+
+			if api.calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", api.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestGetResolverRulesFetchesEachRuleOnce(t *testing.T) {
+	var (
+		api *fakeRoute53Resolver = &fakeRoute53Resolver{
+			pageSize: 2,
+			associations: []r53rtypes.ResolverRuleAssociation{
+				{Id: aws.String("rslvr-rrassoc-1"), ResolverRuleId: aws.String("rslvr-rr-1"), Status: r53rtypes.ResolverRuleAssociationStatusComplete},
+				{Id: aws.String("rslvr-rrassoc-2"), ResolverRuleId: aws.String("rslvr-rr-1"), Status: r53rtypes.ResolverRuleAssociationStatusCreating},
+				{Id: aws.String("rslvr-rrassoc-3"), ResolverRuleId: aws.String("rslvr-rr-2"), Status: r53rtypes.ResolverRuleAssociationStatusComplete},
+				{Id: aws.String("rslvr-rrassoc-4"), ResolverRuleId: aws.String("rslvr-rr-3"), Status: r53rtypes.ResolverRuleAssociationStatusDeleting},
+			},
+			ruleCalls: make(map[string]int),
+		}
+		f *Function = &Function{log: logging.NewNopLogger()}
+	)
+
+	rules, err := f.getResolverRules(api, "vpc-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(rules) != 2 || rules["rslvr-rr-1"].DomainName != "rslvr-rr-1.example.com" {
+		t.Errorf("got %+v, want rules rslvr-rr-1 and rslvr-rr-2", rules)
+	}
+
+	if !reflect.DeepEqual(api.ruleCalls, map[string]int{"rslvr-rr-1": 1, "rslvr-rr-2": 1}) {
+		t.Errorf("got GetResolverRule calls %v, want one per rule", api.ruleCalls)
+	}
+}
+
+func TestSubnetCapacity(t *testing.T) {
+	tests := []struct {
+		name         string
+		cidr         string
+		available    int32
+		reservations []xfnd.SubnetCidrReservation
+		want         *xfnd.SubnetCapacity
+	}{
+		{
+			name:      "/24",
+			cidr:      "10.0.0.0/24",
+			available: 200,
+			want:      &xfnd.SubnetCapacity{Total: 251, Used: 51, Free: 200},
+		},
+		{
+			name:      "/28 with reservations",
+			cidr:      "10.0.0.0/28",
+			available: 11,
+			reservations: []xfnd.SubnetCidrReservation{
+				{Cidr: "10.0.0.8/29"},
+				{Cidr: "2001:db8::/80"},
+			},
+			want: &xfnd.SubnetCapacity{Total: 11, Used: 0, Free: 11, Reserved: 8},
+		},
+		{
+			name: "ipv6 only",
+			cidr: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subnetCapacity(tt.cidr, tt.available, tt.reservations); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeTags builds EC2 tags from key value pairs
+func fakeTags(kv ...string) (tags []ec2types.Tag) {
+	for i := 0; i+1 < len(kv); i += 2 {
+		tags = append(tags, ec2types.Tag{Key: aws.String(kv[i]), Value: aws.String(kv[i+1])})
+	}
+	return
+}
+
+// fakeSubnet builds an IPv4 subnet named after its ID
+func fakeSubnet(id, cidr string, tags ...string) ec2types.Subnet {
+	return ec2types.Subnet{
+		SubnetId:                aws.String(id),
+		SubnetArn:               aws.String("arn:aws:ec2:eu-west-1:123456789012:subnet/" + id),
+		AvailabilityZone:        aws.String("eu-west-1a"),
+		CidrBlock:               aws.String(cidr),
+		AvailableIpAddressCount: aws.Int32(10),
+		Tags:                    fakeTags(append([]string{"Name", id}, tags...)...),
+	}
+}
+
+// fakeRouteTable builds a route table named after its ID, with a local route
+// plus a default route via `gateway` when set
+func fakeRouteTable(id, gateway string, associations ...ec2types.RouteTableAssociation) ec2types.RouteTable {
+	var rt ec2types.RouteTable = ec2types.RouteTable{
+		RouteTableId: aws.String(id),
+		Associations: associations,
+		Routes: []ec2types.Route{
+			{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")},
+		},
+		Tags: fakeTags("Name", id),
+	}
+
+	switch {
+	case strings.HasPrefix(gateway, "igw-"):
+		rt.Routes = append(rt.Routes, ec2types.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String(gateway)})
+	case strings.HasPrefix(gateway, "nat-"):
+		rt.Routes = append(rt.Routes, ec2types.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String(gateway)})
+	}
+	return rt
+}
+
+// fakeAssociation associates a route table with a subnet, or marks it as the
+// main route table when no subnet is given
+func fakeAssociation(id, subnet string, state ec2types.RouteTableAssociationStateCode) ec2types.RouteTableAssociation {
+	var assoc ec2types.RouteTableAssociation = ec2types.RouteTableAssociation{
+		RouteTableAssociationId: aws.String(id),
+		Main:                    aws.Bool(subnet == ""),
+		AssociationState:        &ec2types.RouteTableAssociationState{State: state},
+	}
+
+	if subnet != "" {
+		assoc.SubnetId = aws.String(subnet)
+	}
+	return assoc
+}
+
+func TestGetSubnetsFallsBackToMainRouteTable(t *testing.T) {
+	tests := []struct {
+		name       string
+		gateway    string
+		wantPublic bool
+	}{
+		{name: "public main route table", gateway: "igw-1", wantPublic: true},
+		{name: "private main route table", gateway: "nat-1", wantPublic: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var api *fakeEc2 = &fakeEc2{
+				pageSize: 5,
+				subnets: []ec2types.Subnet{
+					fakeSubnet("subnet-implicit", "10.0.0.0/24"),
+					fakeSubnet("subnet-explicit", "10.0.1.0/24"),
+				},
+				routeTables: []ec2types.RouteTable{
+					fakeRouteTable("rtb-main", tt.gateway, fakeAssociation("rtbassoc-main", "", ec2types.RouteTableAssociationStateCodeAssociated)),
+					fakeRouteTable("rtb-explicit", "", fakeAssociation("rtbassoc-explicit", "subnet-explicit", ec2types.RouteTableAssociationStateCodeAssociated)),
+				},
+			}
+
+			f := &Function{log: logging.NewNopLogger()}
+			_, subnets, err := f.getSubnets(api, "vpc-1", &inp.RemoteVpc{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var sn xfnd.AwsSubnet = subnets["subnet-implicit"]
+			if !sn.ImplicitRouteTableAssociation {
+				t.Errorf("expected implicit route table association")
+			}
+
+			if sn.IsPublic != tt.wantPublic {
+				t.Errorf("got public %t, want %t", sn.IsPublic, tt.wantPublic)
+			}
+
+			if rt, ok := sn.RouteTables["rtb-main"]; !ok || rt.ID != "rtb-main" || !rt.IsImplicit {
+				t.Errorf("expected implicit main route table, got %+v", sn.RouteTables)
+			}
+
+			if sn := subnets["subnet-explicit"]; sn.ImplicitRouteTableAssociation || sn.IsPublic {
+				t.Errorf("expected explicit private subnet, got implicit %t public %t", sn.ImplicitRouteTableAssociation, sn.IsPublic)
+			}
+		})
+	}
+}
+
+func TestGetSubnetsSkipsInactiveAssociations(t *testing.T) {
+	for _, state := range []ec2types.RouteTableAssociationStateCode{
+		ec2types.RouteTableAssociationStateCodeDisassociated,
+		ec2types.RouteTableAssociationStateCodeFailed,
+	} {
+		t.Run(string(state), func(t *testing.T) {
+			// The subnet was previously associated with the public route
+			// table and has since moved to the private one
+			var api *fakeEc2 = &fakeEc2{
+				pageSize: 5,
+				subnets: []ec2types.Subnet{
+					fakeSubnet("subnet-1", "10.0.0.0/24"),
+				},
+				routeTables: []ec2types.RouteTable{
+					fakeRouteTable("rtb-public", "igw-1", fakeAssociation("rtbassoc-old", "subnet-1", state)),
+					fakeRouteTable("rtb-private", "nat-1",
+						fakeAssociation("rtbassoc-older", "subnet-1", state),
+						fakeAssociation("rtbassoc-new", "subnet-1", ec2types.RouteTableAssociationStateCodeAssociated),
+					),
+				},
+			}
+
+			f := &Function{log: logging.NewNopLogger()}
+			_, subnets, err := f.getSubnets(api, "vpc-1", &inp.RemoteVpc{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var sn xfnd.AwsSubnet = subnets["subnet-1"]
+			if sn.IsPublic || sn.InternetGateway != "" {
+				t.Errorf("expected private subnet, got public %t via %q", sn.IsPublic, sn.InternetGateway)
+			}
+
+			if _, ok := sn.RouteTables["rtb-public"]; ok || len(sn.RouteTables) != 1 {
+				t.Fatalf("expected only rtb-private, got %+v", sn.RouteTables)
+			}
+
+			var associations []xfnd.AwsAssociation = sn.RouteTables["rtb-private"].Associations
+			if len(associations) != 1 || associations[0].ID != "rtbassoc-new" {
+				t.Errorf("expected only the active association, got %+v", associations)
+			}
+		})
+	}
+}
+
+func TestGetSubnetsIpv6Only(t *testing.T) {
+	var subnet ec2types.Subnet = fakeSubnet("subnet-v6", "")
+	subnet.CidrBlock = nil
+	subnet.AvailableIpAddressCount = aws.Int32(0)
+	subnet.Ipv6Native = aws.Bool(true)
+	subnet.Ipv6CidrBlockAssociationSet = []ec2types.SubnetIpv6CidrBlockAssociation{
+		{
+			Ipv6CidrBlock:      aws.String("2001:db8::/64"),
+			Ipv6CidrBlockState: &ec2types.SubnetCidrBlockState{State: ec2types.SubnetCidrBlockStateCodeAssociated},
+		},
+	}
+
+	var api *fakeEc2 = &fakeEc2{
+		pageSize: 5,
+		subnets:  []ec2types.Subnet{subnet},
+		routeTables: []ec2types.RouteTable{
+			fakeRouteTable("rtb-1", "", fakeAssociation("rtbassoc-1", "subnet-v6", ec2types.RouteTableAssociationStateCodeAssociated)),
+		},
+	}
+
+	f := &Function{log: logging.NewNopLogger()}
+	_, subnets, err := f.getSubnets(api, "vpc-1", &inp.RemoteVpc{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sn xfnd.AwsSubnet = subnets["subnet-v6"]
+	if sn.CidrBlock != "" || !sn.Ipv6Native || !sn.IsIpv6 || sn.Ipv6CidrBlock != "2001:db8::/64" {
+		t.Errorf("unexpected subnet %+v", sn)
+	}
+
+	if sn.Capacity != nil {
+		t.Errorf("expected no IPv4 capacity, got %+v", sn.Capacity)
+	}
+
+	if c := subnetCapacity(sn.CidrBlock, sn.AvailableIpAddressCount, nil); c != nil {
+		t.Errorf("expected no IPv4 capacity, got %+v", c)
+	}
+}
+
+// fakeVpc builds a VPC with the given tags
+func fakeVpc(id string, tags ...string) ec2types.Vpc {
+	return ec2types.Vpc{
+		VpcId:     aws.String(id),
+		CidrBlock: aws.String("10.0.0.0/16"),
+		OwnerId:   aws.String("123456789012"),
+		Tags:      fakeTags(tags...),
+	}
+}
+
+func TestSelectVpc(t *testing.T) {
+	var (
+		newest    = &inp.AmbiguityPolicy{Policy: inp.AmbiguityPolicyNewest, TimestampTag: "created"}
+		preferKey = &inp.AmbiguityPolicy{Policy: inp.AmbiguityPolicyPreferTag, Tag: &inp.TagFilter{Key: "primary"}}
+		preferVal = &inp.AmbiguityPolicy{Policy: inp.AmbiguityPolicyPreferTag, Tag: &inp.TagFilter{Key: "role", Value: "primary"}}
+	)
+
+	tests := []struct {
+		name    string
+		vpcs    []ec2types.Vpc
+		policy  *inp.AmbiguityPolicy
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "no policy",
+			vpcs:    []ec2types.Vpc{fakeVpc("vpc-1"), fakeVpc("vpc-2")},
+			wantErr: true,
+		},
+		{
+			name:    "error policy",
+			vpcs:    []ec2types.Vpc{fakeVpc("vpc-1"), fakeVpc("vpc-2")},
+			policy:  &inp.AmbiguityPolicy{Policy: inp.AmbiguityPolicyError},
+			wantErr: true,
+		},
+		{
+			name:    "unknown policy",
+			vpcs:    []ec2types.Vpc{fakeVpc("vpc-1"), fakeVpc("vpc-2")},
+			policy:  &inp.AmbiguityPolicy{Policy: "oldest"},
+			wantErr: true,
+		},
+		{
+			name: "newest",
+			vpcs: []ec2types.Vpc{
+				fakeVpc("vpc-1", "created", "2024-01-01T00:00:00Z"),
+				fakeVpc("vpc-2", "created", "2024-06-01T00:00:00Z"),
+				fakeVpc("vpc-3", "created", "2024-03-01T00:00:00Z"),
+			},
+			policy: newest,
+			want:   "vpc-2",
+		},
+		{
+			name: "newest tie goes to the lowest ID",
+			vpcs: []ec2types.Vpc{
+				fakeVpc("vpc-3", "created", "2024-06-01T00:00:00Z"),
+				fakeVpc("vpc-2", "created", "2024-06-01T00:00:00Z"),
+				fakeVpc("vpc-1", "created", "2024-01-01T00:00:00Z"),
+			},
+			policy: newest,
+			want:   "vpc-2",
+		},
+		{
+			name: "newest ignores missing and invalid timestamps",
+			vpcs: []ec2types.Vpc{
+				fakeVpc("vpc-1"),
+				fakeVpc("vpc-2", "created", "yesterday"),
+				fakeVpc("vpc-3", "created", "2024-01-01T00:00:00Z"),
+			},
+			policy: newest,
+			want:   "vpc-3",
+		},
+		{
+			name: "newest without any valid timestamp",
+			vpcs: []ec2types.Vpc{
+				fakeVpc("vpc-1"),
+				fakeVpc("vpc-2", "created", "yesterday"),
+			},
+			policy:  newest,
+			wantErr: true,
+		},
+		{
+			name:    "newest without a timestamp tag",
+			vpcs:    []ec2types.Vpc{fakeVpc("vpc-1"), fakeVpc("vpc-2")},
+			policy:  &inp.AmbiguityPolicy{Policy: inp.AmbiguityPolicyNewest},
+			wantErr: true,
+		},
+		{
+			name:    "preferTag with no match",
+			vpcs:    []ec2types.Vpc{fakeVpc("vpc-1"), fakeVpc("vpc-2", "role", "secondary")},
+			policy:  preferVal,
+			wantErr: true,
+		},
+		{
+			name:   "preferTag with one match",
+			vpcs:   []ec2types.Vpc{fakeVpc("vpc-1", "role", "secondary"), fakeVpc("vpc-2", "role", "primary")},
+			policy: preferVal,
+			want:   "vpc-2",
+		},
+		{
+			name:   "preferTag with one match on key only",
+			vpcs:   []ec2types.Vpc{fakeVpc("vpc-1"), fakeVpc("vpc-2", "primary", "")},
+			policy: preferKey,
+			want:   "vpc-2",
+		},
+		{
+			name: "preferTag with several matches",
+			vpcs: []ec2types.Vpc{
+				fakeVpc("vpc-1", "role", "primary"),
+				fakeVpc("vpc-2", "role", "secondary"),
+				fakeVpc("vpc-3", "role", "primary"),
+			},
+			policy:  preferVal,
+			wantErr: true,
+		},
+		{
+			name:    "preferTag without a tag",
+			vpcs:    []ec2types.Vpc{fakeVpc("vpc-1"), fakeVpc("vpc-2")},
+			policy:  &inp.AmbiguityPolicy{Policy: inp.AmbiguityPolicyPreferTag},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vpc, err := selectVpc(tt.vpcs, tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+
+			if got := aws.ToString(vpc.VpcId); !tt.wantErr && got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetVpcReportsMatches(t *testing.T) {
+	var vpcs []ec2types.Vpc = []ec2types.Vpc{
+		fakeVpc("vpc-1", "role", "secondary"),
+		fakeVpc("vpc-2", "role", "primary"),
+	}
+
+	tests := []struct {
+		name    string
+		policy  *inp.AmbiguityPolicy
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "error",
+			policy:  &inp.AmbiguityPolicy{Policy: inp.AmbiguityPolicyError},
+			wantErr: true,
+		},
+		{
+			name:   "preferTag",
+			policy: &inp.AmbiguityPolicy{Policy: inp.AmbiguityPolicyPreferTag, Tag: &inp.TagFilter{Key: "role", Value: "primary"}},
+			want:   "vpc-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var api *fakeEc2 = &fakeEc2{pageSize: 5, vpcs: vpcs}
+
+			f := &Function{log: logging.NewNopLogger()}
+			v, err := f.getVpc(api, &ec2.DescribeVpcsInput{}, &inp.RemoteVpc{Name: "shared", Ambiguity: tt.policy})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+
+				for _, id := range []string{"vpc-1", "vpc-2"} {
+					if !strings.Contains(err.Error(), id) {
+						t.Errorf("expected %q in error %q", id, err)
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if v.ID != tt.want {
+				t.Errorf("got %q, want %q", v.ID, tt.want)
+			}
+
+			if !reflect.DeepEqual(v.Matches, []string{"vpc-1", "vpc-2"}) {
+				t.Errorf("got matches %v, want both VPCs", v.Matches)
+			}
+		})
+	}
+}
+
+func TestGetSubnetsCountsSets(t *testing.T) {
+	var api *fakeEc2 = &fakeEc2{
+		pageSize: 5,
+		subnets: []ec2types.Subnet{
+			fakeSubnet("subnet-0", "10.0.0.0/24"),
+			fakeSubnet("subnet-2", "10.0.2.0/24", "set", "2"),
+			fakeSubnet("subnet-negative", "10.0.3.0/24", "set", "-1"),
+			fakeSubnet("subnet-invalid", "10.0.4.0/24", "set", "one"),
+		},
+		routeTables: []ec2types.RouteTable{
+			fakeRouteTable("rtb-main", "", fakeAssociation("rtbassoc-main", "", ec2types.RouteTableAssociationStateCodeAssociated)),
+		},
+	}
+
+	f := &Function{log: logging.NewNopLogger()}
+	count, subnets, err := f.getSubnets(api, "vpc-1", &inp.RemoteVpc{GroupBy: "set"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if count != 3 {
+		t.Errorf("got %d sets, want 3", count)
+	}
+
+	for name, want := range map[string]int{"subnet-0": 0, "subnet-2": 2, "subnet-negative": 0, "subnet-invalid": 0} {
+		if got := subnets[name].SubnetSet; got != want {
+			t.Errorf("%s: got set %d, want %d", name, got, want)
+		}
+	}
+}
+
+func TestGetVpcKeepsSubnetSetsAligned(t *testing.T) {
+	// Each subnet uses its own route table, public subnets route through an
+	// internet gateway and private subnets through a NAT gateway
+	type subnet struct {
+		id     string
+		cidr   string
+		set    string
+		public bool
+	}
+
+	tests := []struct {
+		name        string
+		subnets     []subnet
+		wantPublic  [][]string
+		wantPrivate [][]string
+		wantTotals  []int64
+	}{
+		{
+			name: "sparse sets",
+			subnets: []subnet{
+				{id: "subnet-public-1", cidr: "10.0.1.0/24", set: "1", public: true},
+				{id: "subnet-private-1", cidr: "10.0.2.0/25", set: "1"},
+				{id: "subnet-private-3", cidr: "10.0.3.0/26", set: "3"},
+			},
+			wantPublic:  [][]string{{}, {"subnet-public-1"}},
+			wantPrivate: [][]string{{}, {"subnet-private-1"}, {}, {"subnet-private-3"}},
+			wantTotals:  []int64{0, 251 + 123, 0, 59},
+		},
+		{
+			name: "private only set before a public set",
+			subnets: []subnet{
+				{id: "subnet-private-0", cidr: "10.0.0.0/26", set: "0"},
+				{id: "subnet-public-1", cidr: "10.0.1.0/24", set: "1", public: true},
+				{id: "subnet-private-1", cidr: "10.0.2.0/25", set: "1"},
+			},
+			wantPublic:  [][]string{{}, {"subnet-public-1"}},
+			wantPrivate: [][]string{{"subnet-private-0"}, {"subnet-private-1"}},
+			wantTotals:  []int64{59, 251 + 123},
+		},
+		{
+			name: "private only sets",
+			subnets: []subnet{
+				{id: "subnet-private-0", cidr: "10.0.0.0/26", set: "0"},
+				{id: "subnet-private-1", cidr: "10.0.2.0/25", set: "1"},
+			},
+			wantPublic:  [][]string{},
+			wantPrivate: [][]string{{"subnet-private-0"}, {"subnet-private-1"}},
+			wantTotals:  []int64{59, 123},
+		},
+	}
+
+	var names = func(sets []xfnd.StatusSubnets) [][]string {
+		var out [][]string = make([][]string, 0, len(sets))
+		for _, set := range sets {
+			var n []string = []string{}
+			for name := range set {
+				n = append(n, name)
+			}
+			out = append(out, n)
+		}
+		return out
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var api *fakeEc2 = &fakeEc2{pageSize: 5, vpcs: []ec2types.Vpc{fakeVpc("vpc-1")}}
+			for i, sn := range tt.subnets {
+				var gateway string = "nat-1"
+				if sn.public {
+					gateway = "igw-1"
+				}
+
+				api.subnets = append(api.subnets, fakeSubnet(sn.id, sn.cidr, "set", sn.set))
+				api.routeTables = append(api.routeTables, fakeRouteTable("rtb-"+sn.id, gateway,
+					fakeAssociation("rtbassoc-"+strconv.Itoa(i), sn.id, ec2types.RouteTableAssociationStateCodeAssociated),
+				))
+			}
+
+			f := &Function{log: logging.NewNopLogger()}
+			v, err := f.getVpc(api, &ec2.DescribeVpcsInput{}, &inp.RemoteVpc{GroupBy: "set"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := names(v.PublicSubnets); !reflect.DeepEqual(got, tt.wantPublic) {
+				t.Errorf("got public subnets %v, want %v", got, tt.wantPublic)
+			}
+
+			if got := names(v.PrivateSubnets); !reflect.DeepEqual(got, tt.wantPrivate) {
+				t.Errorf("got private subnets %v, want %v", got, tt.wantPrivate)
+			}
+
+			var totals []int64 = make([]int64, 0, len(v.SubnetSetCapacity))
+			for _, c := range v.SubnetSetCapacity {
+				totals = append(totals, c.Total)
+			}
+
+			if !reflect.DeepEqual(totals, tt.wantTotals) {
+				t.Errorf("got set capacity totals %v, want %v", totals, tt.wantTotals)
+			}
+
+			if len(v.PublicRouteTables) != len(tt.wantPublic) || len(v.PrivateRouteTables) != len(tt.wantPrivate) {
+				t.Errorf("got %d public and %d private route table sets, want %d and %d",
+					len(v.PublicRouteTables), len(v.PrivateRouteTables), len(tt.wantPublic), len(tt.wantPrivate))
+			}
+		})
+	}
+}
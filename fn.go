@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"sync"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
@@ -86,6 +87,24 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1beta1.RunFunctionRequ
 		return rsp, nil
 	}
 
+	for i := range search {
+		if search[i].Ambiguity == nil {
+			search[i].Ambiguity = input.Spec.Ambiguity
+		}
+
+		if search[i].SecurityGroups == nil {
+			search[i].SecurityGroups = input.Spec.SecurityGroups
+		}
+
+		if search[i].TransitGatewayAttachments == nil {
+			search[i].TransitGatewayAttachments = input.Spec.TransitGatewayAttachments
+		}
+
+		if search[i].Discover == nil {
+			search[i].Discover = input.Spec.Discover
+		}
+	}
+
 	switch input.Spec.ProviderType {
 	case "aws":
 		current := inp.RemoteVpc{
@@ -94,7 +113,11 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1beta1.RunFunctionRequ
 			ProviderConfig: providerConfig,
 			GroupBy:        groupTag,
 		}
-		err = f.awsVpcs(search, current, input.Spec.PatchTo, composed)
+		var errs []error
+		errs, err = f.awsVpcs(search, current, input.Spec.PatchTo, composed)
+		for _, e := range errs {
+			response.Warning(rsp, e)
+		}
 	default:
 		f.log.Info("provider type not supported", "type", input.Spec.ProviderType)
 		response.Fatal(rsp, errors.New("provider type not supported"))
@@ -116,34 +139,80 @@ func (f *Function) RunFunction(_ context.Context, req *fnv1beta1.RunFunctionRequ
 	return rsp, nil
 }
 
-func (f *Function) awsVpcs(search []inp.RemoteVpc, current inp.RemoteVpc, patchTo string, composed *composite.Composition) (err error) {
-	var vpcs AwsVpcs = make(AwsVpcs)
+func (f *Function) awsVpcs(search []inp.RemoteVpc, current inp.RemoteVpc, patchTo string, composed *composite.Composition) (errs []error, err error) {
+	var (
+		vpcs    AwsVpcs        = make(AwsVpcs)
+		results []fnc.AwsVpc   = make([]fnc.AwsVpc, len(search))
+		failed  []error        = make([]error, len(search))
+		hasSelf bool           = false
+		wg      sync.WaitGroup = sync.WaitGroup{}
+		sem     chan struct{}  = make(chan struct{}, f.concurrency())
+	)
 	{
 		for _, n := range search {
-			n := n
-			var vpc fnc.AwsVpc
-			if vpc, err = f.ReadVpc(&n); err != nil {
-				f.log.Info("cannot read VPC", "error", err, "name", n.Name, "region", n.Region, "providerConfig", n.ProviderConfig)
+			if n.Name == "self" {
+				hasSelf = true
+			}
+		}
+
+		// The account lookup for `self` is only required when it hasn't been
+		// requested as a search item so start it alongside the VPC lookups.
+		var (
+			self    fnc.AwsVpc
+			selfErr error
+		)
+		if !hasSelf {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				self, selfErr = f.selfVpc(current)
+			}()
+		}
+
+		for i, n := range search {
+			i, n := i, n
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				results[i], failed[i] = f.ReadVpc(&n)
+			}()
+		}
+		wg.Wait()
+
+		// Results are merged in search order so that the output stays
+		// deterministic regardless of which lookup completes first.
+		for i, n := range search {
+			if failed[i] != nil {
+				f.log.Info("cannot read VPC", "error", failed[i], "name", n.Name, "region", n.Region, "providerConfig", n.ProviderConfig)
+				errs = append(errs, errors.Wrapf(failed[i], "cannot read VPC %q", n.Name))
 				continue
 			}
 
 			// Copy the  provider config and region from the search input so the
 			// composition doesn't have to re-match it on cross-account lookups.
+			vpc := results[i]
 			vpc.Region = n.Region
 			vpc.ProviderConfig = n.ProviderConfig
 			vpcs[n.Name] = vpc
+
+			if len(vpc.Matches) > 1 {
+				errs = append(errs, errors.Errorf("VPC %q matched %d VPCs %v, using %s", n.Name, len(vpc.Matches), vpc.Matches, vpc.ID))
+			}
 		}
 
 		if _, ok := vpcs["self"]; !ok {
-			var id string
-			if id, err = f.GetAccountId(&current.Region, &current.ProviderConfig); err != nil {
-				f.log.Info("cannot get account ID", "error", err)
+			if hasSelf {
+				self, selfErr = f.selfVpc(current)
+			}
+
+			if selfErr != nil {
+				f.log.Info("cannot get account ID", "error", selfErr)
 			} else {
-				vpcs["self"] = fnc.AwsVpc{
-					Owner:          id,
-					ProviderConfig: current.ProviderConfig,
-					Region:         current.Region,
-				}
+				vpcs["self"] = self
 			}
 		}
 		f.log.Info("VPCs", "vpcs", vpcs)
@@ -152,6 +221,22 @@ func (f *Function) awsVpcs(search []inp.RemoteVpc, current inp.RemoteVpc, patchT
 	return
 }
 
+// selfVpc builds the `self` entry from the account the function is running
+// against
+func (f *Function) selfVpc(current inp.RemoteVpc) (vpc fnc.AwsVpc, err error) {
+	var id string
+	if id, err = f.GetAccountId(&current.Region, &current.ProviderConfig); err != nil {
+		return
+	}
+
+	vpc = fnc.AwsVpc{
+		Owner:          id,
+		ProviderConfig: current.ProviderConfig,
+		Region:         current.Region,
+	}
+	return
+}
+
 // get array from paved
 func (f *Function) getValueInto(req runtime.Object, ref, region, providerConfig, groupBy string, value *[]inp.RemoteVpc) (err error) {
 	var paved *fieldpath.Paved
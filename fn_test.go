@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/function-sdk-go/resource"
+	xrcomposite "github.com/crossplane/function-sdk-go/resource/composite"
+	"github.com/giantswarm/xfnlib/pkg/composite"
+
+	inp "github.com/giantswarm/crossplane-fn-network-discovery/pkg/input/v1beta1"
+)
+
+// fakeSts returns a fixed caller identity
+type fakeSts struct{}
+
+func (fakeSts) GetCallerIdentity(_ context.Context, _ *sts.GetCallerIdentityInput, _ ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{
+		Account: aws.String("123456789012"),
+		Arn:     aws.String("arn:aws:iam::123456789012:user/test"),
+		UserId:  aws.String("test"),
+	}, nil
+}
+
+// vpcTracker records how many VPC lookups run at the same time
+type vpcTracker struct {
+	mu     sync.Mutex
+	active int
+	max    int
+}
+
+// trackedEc2 answers VPC lookups by name, returning an error for any name
+// starting with `broken` and sleeping for `delay` to let lookups overlap.
+type trackedEc2 struct {
+	*fakeEc2
+	tracker *vpcTracker
+	delay   map[string]time.Duration
+}
+
+func (f *trackedEc2) DescribeVpcs(_ context.Context, params *ec2.DescribeVpcsInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
+	var name string
+	for _, filter := range params.Filters {
+		if aws.ToString(filter.Name) == "tag:Name" && len(filter.Values) > 0 {
+			name = filter.Values[0]
+		}
+	}
+
+	f.tracker.mu.Lock()
+	f.tracker.active++
+	f.tracker.max = max(f.tracker.max, f.tracker.active)
+	f.tracker.mu.Unlock()
+
+	time.Sleep(10*time.Millisecond + f.delay[name])
+
+	f.tracker.mu.Lock()
+	f.tracker.active--
+	f.tracker.mu.Unlock()
+
+	if strings.HasPrefix(name, "broken") {
+		return nil, errors.New("access denied")
+	}
+
+	return &ec2.DescribeVpcsOutput{
+		Vpcs: []ec2types.Vpc{
+			{
+				VpcId:     aws.String("vpc-" + name),
+				CidrBlock: aws.String("10.0.0.0/16"),
+				OwnerId:   aws.String("123456789012"),
+			},
+		},
+	}, nil
+}
+
+func TestAwsVpcs(t *testing.T) {
+	defer func(c, e, s any) {
+		awsConfig = c.(func(*string, *string, logging.Logger) (aws.Config, map[string]string, error))
+		getEc2Client = e.(func(aws.Config, string) AwsEc2Api)
+		getStsClient = s.(func(aws.Config, string) AwsStsApi)
+	}(awsConfig, getEc2Client, getStsClient)
+
+	var tracker *vpcTracker = &vpcTracker{}
+	awsConfig = func(_, _ *string, _ logging.Logger) (aws.Config, map[string]string, error) {
+		return aws.Config{}, map[string]string{}, nil
+	}
+	getEc2Client = func(_ aws.Config, _ string) AwsEc2Api {
+		// The first broken VPC finishes last so the merge order can't
+		// follow completion order.
+		return &trackedEc2{
+			fakeEc2: &fakeEc2{pageSize: 5},
+			tracker: tracker,
+			delay:   map[string]time.Duration{"broken-a": 50 * time.Millisecond},
+		}
+	}
+	getStsClient = func(_ aws.Config, _ string) AwsStsApi {
+		return fakeSts{}
+	}
+
+	var search []inp.RemoteVpc = []inp.RemoteVpc{
+		{Name: "one", Region: "eu-west-1"},
+		{Name: "broken-a", Region: "eu-west-1"},
+		{Name: "two", Region: "eu-west-1"},
+		{Name: "three", Region: "eu-west-1"},
+		{Name: "broken-b", Region: "eu-west-1"},
+		{Name: "four", Region: "eu-west-1"},
+	}
+
+	var f *Function = &Function{log: logging.NewNopLogger(), maxConcurrency: 2}
+	var composed *composite.Composition = &composite.Composition{
+		DesiredComposite: &resource.Composite{Resource: xrcomposite.New()},
+	}
+	composed.DesiredComposite.Resource.SetAPIVersion("example.org/v1")
+	composed.DesiredComposite.Resource.SetKind("XNetwork")
+
+	errs, err := f.awsVpcs(search, inp.RemoteVpc{Region: "eu-west-1"}, "status.vpcs", composed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tracker.max > f.maxConcurrency {
+		t.Errorf("expected at most %d concurrent lookups, got %d", f.maxConcurrency, tracker.max)
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 warnings, got %d: %v", len(errs), errs)
+	}
+	for i, name := range []string{"broken-a", "broken-b"} {
+		if !strings.Contains(errs[i].Error(), `"`+name+`"`) {
+			t.Errorf("expected warning %d for %q, got %v", i, name, errs[i])
+		}
+	}
+
+	paved, err := fieldpath.PaveObject(composed.DesiredComposite.Resource)
+	if err != nil {
+		t.Fatalf("cannot pave composite: %v", err)
+	}
+
+	var vpcs AwsVpcs
+	if err = paved.GetValueInto("status.vpcs", &vpcs); err != nil {
+		t.Fatalf("cannot read vpcs: %v", err)
+	}
+
+	for _, name := range []string{"one", "two", "three", "four"} {
+		if vpcs[name].ID != "vpc-"+name {
+			t.Errorf("expected VPC %q to have ID %q, got %q", name, "vpc-"+name, vpcs[name].ID)
+		}
+	}
+
+	for _, name := range []string{"broken-a", "broken-b"} {
+		if _, ok := vpcs[name]; ok {
+			t.Errorf("expected VPC %q to be missing", name)
+		}
+	}
+
+	if vpcs["self"].Owner != "123456789012" {
+		t.Errorf("expected self owner %q, got %q", "123456789012", vpcs["self"].Owner)
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	for _, tc := range []struct {
+		maxConcurrency int
+		expected       int
+	}{
+		{maxConcurrency: -1, expected: 1},
+		{maxConcurrency: 0, expected: 1},
+		{maxConcurrency: 1, expected: 1},
+		{maxConcurrency: 3, expected: 3},
+	} {
+		f := &Function{maxConcurrency: tc.maxConcurrency}
+		if got := f.concurrency(); got != tc.expected {
+			t.Errorf("concurrency(%d): expected %d, got %d", tc.maxConcurrency, tc.expected, got)
+		}
+	}
+}
@@ -39,105 +39,1080 @@ type StatusSubnetDetails struct {
 	//
 	// +required
 	ID string `json:"id"`
+
+	// The availability zone the subnet is located in
+	//
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// The ID of the availability zone the subnet is located in. Unlike the
+	// zone name, the ID refers to the same physical zone in every account
+	//
+	// +optional
+	AvailabilityZoneID string `json:"availabilityZoneId,omitempty"`
+
+	// The type of zone the subnet is located in, one of availability-zone,
+	// local-zone or wavelength-zone
+	//
+	// +optional
+	AvailabilityZoneType string `json:"availabilityZoneType,omitempty"`
+
+	// The number of unused IPv4 addresses in the subnet
+	//
+	// +optional
+	AvailableIpAddressCount int32 `json:"availableIpAddressCount"`
+
+	// The IPv4 address capacity of the subnet. Not set for IPv6 only subnets
+	//
+	// +optional
+	Capacity *SubnetCapacity `json:"capacity,omitempty"`
+
+	// The Ipv4 cidr block of the subnet. Empty for IPv6 only subnets
+	//
+	// +optional
+	CidrBlock string `json:"cidrBlock,omitempty"`
+
+	// The CIDR reservations defined in the subnet. Only populated when CIDR
+	// reservation discovery is enabled
+	//
+	// +listType=atomic
+	// +optional
+	CidrReservations []SubnetCidrReservation `json:"cidrReservations,omitempty"`
+
+	// Is this the default subnet for its availability zone
+	//
+	// +optional
+	DefaultForAz bool `json:"defaultForAz"`
+
+	// Is this subnet implicitly associated with the VPC main route table
+	//
+	// +optional
+	ImplicitRouteTableAssociation bool `json:"implicitRouteTableAssociation"`
+
+	// The ID of the network ACL associated with this subnet
+	//
+	// +optional
+	NetworkAclID string `json:"networkAclId,omitempty"`
+
+	// Is this subnet enabled for IPv6
+	//
+	// +optional
+	IsIpv6 bool `json:"isIpV6"`
+
+	// The IPv6 cidr block of the subnet
+	//
+	// +optional
+	Ipv6CidrBlock string `json:"ipv6CidrBlock,omitempty"`
+
+	// Is this an IPv6 only subnet without an IPv4 cidr block
+	//
+	// +optional
+	Ipv6Native bool `json:"ipv6Native"`
+}
+
+// SubnetCapacity holds the IPv4 address capacity of a subnet or a group of
+// subnets
+type SubnetCapacity struct {
+	// The number of usable addresses, excluding the five AWS reserves in
+	// every subnet
+	//
+	// +optional
+	Total int64 `json:"total"`
+
+	// The number of addresses in use
+	//
+	// +optional
+	Used int64 `json:"used"`
+
+	// The number of addresses available for use
+	//
+	// +optional
+	Free int64 `json:"free"`
+
+	// The number of addresses covered by IPv4 CIDR reservations. Always 0
+	// unless CIDR reservation discovery is enabled
+	//
+	// +optional
+	Reserved int64 `json:"reserved"`
+}
+
+type SubnetCidrReservation struct {
+	// The ID of the CIDR reservation
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The reserved CIDR block
+	//
+	// +optional
+	Cidr string `json:"cidr"`
+
+	// The description of the CIDR reservation
+	//
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The type of the reservation, either prefix or explicit
+	//
+	// +optional
+	ReservationType string `json:"reservationType"`
+}
+
+// StatusRouteTables is a map of route tables and their status
+//
+// +mapType=atomic
+type StatusRouteTables map[string]StatusRouteTableDetails
+
+type StatusRouteTableDetails struct {
+	// The ID of the route table
+	//
+	// +required
+	ID string `json:"id"`
+
+	// The IDs of the virtual private gateways propagating routes to this
+	// route table
+	//
+	// +listType=atomic
+	// +optional
+	PropagatingVpnGateways []string `json:"propagatingVpnGateways,omitempty"`
+
+	// The routes defined in this route table keyed by their destination
+	//
+	// +mapType=granular
+	// +optional
+	Routes map[string]AwsRoute `json:"routes,omitempty"`
+}
+
+// Vpc holds VPC information
+//
+// +structType=granular
+type AwsVpc struct {
+	// A list of additional VPC CIDR blocks defined in this VPC
+	// +listType=atomic
+	// +optional
+	AdditionalCidrBlocks []string `json:"additionalCidrBlocks,omitempty"`
+
+	// The zones of the VPC region the account is opted in to
+	// +listType=atomic
+	// +optional
+	AvailabilityZones []AvailabilityZone `json:"availabilityZones,omitempty"`
+
+	// The IPv4 address capacity summed over all subnets of this VPC
+	// +optional
+	Capacity *SubnetCapacity `json:"capacity,omitempty"`
+
+	// The Ipv4 cidr block defined for this VPC
+	// +optional
+	CidrBlock string `json:"cidrBlock,omitempty"`
+
+	// A map of customer gateways used by VPN connections of this VPC keyed
+	// by ID
+	// +mapType=atomic
+	// +optional
+	CustomerGateways map[string]CustomerGateway `json:"customerGateways,omitempty"`
+
+	// A map of Direct Connect gateway associations of the VPN gateway and
+	// transit gateways discovered for this VPC keyed by association ID
+	// +mapType=atomic
+	// +optional
+	DirectConnectGatewayAssociations map[string]DirectConnectGatewayAssociation `json:"directConnectGatewayAssociations,omitempty"`
+
+	// The DHCP options set attached to this VPC. Not set when the VPC uses
+	// the default options
+	// +optional
+	DhcpOptions *DhcpOptions `json:"dhcpOptions,omitempty"`
+
+	// The private hosted zones and Resolver rules associated with this VPC.
+	// Only populated when DNS discovery is enabled
+	// +optional
+	DNS *VpcDns `json:"dns,omitempty"`
+
+	// The egress only internet gateway defined in this VPC
+	// +optional
+	EgressOnlyInternetGateway string `json:"egressOnlyInternetGateway,omitempty"`
+
+	// ID The VPC ID
+	// +kubebuilder:validation:Required
+	// +required
+	ID string `json:"id,omitempty"`
+
+	// The internet gateway defined in this VPC
+	// +optional
+	InternetGateway string `json:"internetGateway,omitempty"`
+
+	// A list of IPv6 cidr blocks associated with this VPC
+	// +listType=atomic
+	// +optional
+	Ipv6CidrBlocks []string `json:"ipv6CidrBlocks,omitempty"`
+
+	// The IDs of all VPCs that matched the lookup when more than one was found
+	// +listType=atomic
+	// +optional
+	Matches []string `json:"matches,omitempty"`
+
+	// A map of NAT gateways defined in this VPC
+	// +mapType=atomic
+	// +optional
+	NatGateways map[string]NatGateway `json:"natGateways,omitempty"`
+
+	// A map of network ACLs defined in this VPC keyed by ID
+	// +mapType=atomic
+	// +optional
+	NetworkAcls map[string]NetworkAcl `json:"networkAcls,omitempty"`
+
+	// The owner of the current VPC
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// The provider config used to look up this VPC
+	// +optional
+	ProviderConfig string `json:"providerConfig,omitempty"`
+
+	// A map of private subnets defined in this VPC
+	// +listType=atomic
+	// +optional
+	PrivateSubnets []StatusSubnets `json:"privateSubnets,omitempty"`
+
+	// A list of maps of public subnets defined in this VPC
+	// +listType=atomic
+	// +optional
+	PublicSubnets []StatusSubnets `json:"publicSubnets,omitempty"`
+
+	// A map of private route tables defined in this VPC
+	// +listType=atomic
+	// +optional
+	PrivateRouteTables []StatusRouteTables `json:"privateRouteTables,omitempty"`
+
+	// A map of public route tables defined in this VPC
+	// +listType=atomic
+	// +optional
+	PublicRouteTables []StatusRouteTables `json:"publicRouteTables,omitempty"`
+
+	// The region this VPC is located in
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// A map of security groups defined in this VPC
+	// +mapType=atomic
+	// +optional
+	SecurityGroups map[string]string `json:"securityGroups,omitempty"`
+
+	// A map of security group details keyed by group name. Only populated
+	// when security group details are requested
+	// +mapType=atomic
+	// +optional
+	SecurityGroupDetails map[string]SecurityGroup `json:"securityGroupDetails,omitempty"`
+
+	// The IPv4 address capacity of each subnet set, indexed by set number in
+	// the same way as the public and private subnet lists
+	// +listType=atomic
+	// +optional
+	SubnetSetCapacity []SubnetCapacity `json:"subnetSetCapacity,omitempty"`
+
+	// A map of transit gateways defined in this VPC
+	// +mapType=atomic
+	// +optional
+	TransitGateways map[string]TransitGateway `json:"transitGateways,omitempty"`
+
+	// A map of VPC endpoints defined in this VPC keyed by service name. Each
+	// entry lists every endpoint of the service ordered by ID
+	// +mapType=atomic
+	// +optional
+	VpcEndpoints map[string][]VpcEndpoint `json:"vpcEndpoints,omitempty"`
+
+	// A map of VPC peering connections defined in this VPC
+	// +mapType=atomic
+	// +optional
+	VpcPeeringConnections map[string]PeeringConnection `json:"vpcPeeringConnections,omitempty"`
+
+	// A map of VPN connections terminating on the VPN gateway of this VPC
+	// keyed by ID
+	// +mapType=atomic
+	// +optional
+	VpnConnections map[string]VpnConnection `json:"vpnConnections,omitempty"`
+
+	// The virtual private gateway attached to this VPC
+	// +optional
+	VpnGateway *VpnGateway `json:"vpnGateway,omitempty"`
+}
+
+type AvailabilityZone struct {
+	// The ID of the zone
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The name of the zone
+	//
+	// +optional
+	Name string `json:"name"`
+
+	// The name of the zone group, for example the Local Zone group
+	//
+	// +optional
+	GroupName string `json:"groupName,omitempty"`
+
+	// The network border group the zone advertises IP addresses from
+	//
+	// +optional
+	NetworkBorderGroup string `json:"networkBorderGroup,omitempty"`
+
+	// Whether the account is opted in to the zone, either opted-in or
+	// opt-in-not-required
+	//
+	// +optional
+	OptInStatus string `json:"optInStatus"`
+
+	// The ID of the zone handling control plane operations for a Local Zone
+	// or Wavelength Zone
+	//
+	// +optional
+	ParentZoneID string `json:"parentZoneId,omitempty"`
+
+	// The name of the zone handling control plane operations for a Local
+	// Zone or Wavelength Zone
+	//
+	// +optional
+	ParentZoneName string `json:"parentZoneName,omitempty"`
+
+	// The state of the zone
+	//
+	// +optional
+	State string `json:"state"`
+
+	// The type of the zone, one of availability-zone, local-zone or
+	// wavelength-zone
+	//
+	// +optional
+	Type string `json:"type"`
+}
+
+type CustomerGateway struct {
+	// The ID of the customer gateway
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The BGP ASN of the customer gateway
+	//
+	// +optional
+	BgpAsn string `json:"bgpAsn"`
+
+	// The name of the customer gateway device
+	//
+	// +optional
+	DeviceName string `json:"deviceName,omitempty"`
+
+	// The IP address of the customer gateway outside interface
+	//
+	// +optional
+	IPAddress string `json:"ipAddress"`
+
+	// The name of the customer gateway
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The state of the customer gateway
+	//
+	// +optional
+	State string `json:"state"`
+
+	// The type of VPN connection the customer gateway supports
+	//
+	// +optional
+	Type string `json:"type"`
+}
+
+type DirectConnectGatewayAssociation struct {
+	// The ID of the Direct Connect gateway association
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The prefixes advertised to the Direct Connect gateway
+	//
+	// +listType=atomic
+	// +optional
+	AllowedPrefixes []string `json:"allowedPrefixes,omitempty"`
+
+	// The ID of the VPN gateway or transit gateway that is associated
+	//
+	// +optional
+	AssociatedGatewayID string `json:"associatedGatewayId"`
+
+	// The account that owns the associated gateway
+	//
+	// +optional
+	AssociatedGatewayOwnerAccount string `json:"associatedGatewayOwnerAccount,omitempty"`
+
+	// The type of the associated gateway
+	//
+	// +optional
+	AssociatedGatewayType string `json:"associatedGatewayType"`
+
+	// The ID of the Direct Connect gateway
+	//
+	// +optional
+	DirectConnectGatewayID string `json:"directConnectGatewayId"`
+
+	// The account that owns the Direct Connect gateway
+	//
+	// +optional
+	DirectConnectGatewayOwnerAccount string `json:"directConnectGatewayOwnerAccount,omitempty"`
+
+	// The state of the association
+	//
+	// +optional
+	State string `json:"state"`
+
+	// The error message if the state of the association changed due to an
+	// error
+	//
+	// +optional
+	StateChangeError string `json:"stateChangeError,omitempty"`
+}
+
+type DhcpOptions struct {
+	// The ID of the DHCP options set
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// Whether any domain name server other than AmazonProvidedDNS is
+	// configured
+	//
+	// +optional
+	CustomDomainNameServers bool `json:"customDomainNameServers"`
+
+	// The domain name handed out to instances
+	//
+	// +optional
+	DomainName string `json:"domainName,omitempty"`
+
+	// The domain name servers handed out to instances
+	//
+	// +listType=atomic
+	// +optional
+	DomainNameServers []string `json:"domainNameServers,omitempty"`
+
+	// The preferred lease time for IPv6 addresses in seconds
+	//
+	// +optional
+	Ipv6AddressPreferredLeaseTime string `json:"ipv6AddressPreferredLeaseTime,omitempty"`
+
+	// The name of the DHCP options set
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The NetBIOS name servers handed out to instances
+	//
+	// +listType=atomic
+	// +optional
+	NetbiosNameServers []string `json:"netbiosNameServers,omitempty"`
+
+	// The NetBIOS node type
+	//
+	// +optional
+	NetbiosNodeType string `json:"netbiosNodeType,omitempty"`
+
+	// The NTP servers handed out to instances
+	//
+	// +listType=atomic
+	// +optional
+	NtpServers []string `json:"ntpServers,omitempty"`
+
+	// The account that owns the DHCP options set
+	//
+	// +optional
+	OwnerID string `json:"ownerId,omitempty"`
+}
+
+type VpcDns struct {
+	// A map of Route 53 private hosted zones associated with the VPC keyed by
+	// hosted zone ID
+	//
+	// +mapType=atomic
+	// +optional
+	PrivateHostedZones map[string]PrivateHostedZone `json:"privateHostedZones,omitempty"`
+
+	// A map of Route 53 Resolver rules associated with the VPC keyed by rule
+	// ID
+	//
+	// +mapType=atomic
+	// +optional
+	ResolverRules map[string]ResolverRule `json:"resolverRules,omitempty"`
+}
+
+type PrivateHostedZone struct {
+	// The ID of the hosted zone
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The domain name of the hosted zone
+	//
+	// +optional
+	Name string `json:"name"`
+
+	// The account that owns the hosted zone
+	//
+	// +optional
+	OwningAccount string `json:"owningAccount,omitempty"`
+
+	// The AWS service that owns the hosted zone
+	//
+	// +optional
+	OwningService string `json:"owningService,omitempty"`
+}
+
+type ResolverRule struct {
+	// The ID of the Resolver rule
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The ARN of the Resolver rule
+	//
+	// +optional
+	ARN string `json:"arn,omitempty"`
+
+	// The ID of the association between the rule and the VPC
+	//
+	// +optional
+	AssociationID string `json:"associationId"`
+
+	// The status of the association between the rule and the VPC
+	//
+	// +optional
+	AssociationStatus string `json:"associationStatus"`
+
+	// The domain name queries are forwarded for
+	//
+	// +optional
+	DomainName string `json:"domainName"`
+
+	// The name of the Resolver rule
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The account that created the Resolver rule
+	//
+	// +optional
+	OwnerID string `json:"ownerId,omitempty"`
+
+	// The ID of the outbound Resolver endpoint queries are forwarded through
+	//
+	// +optional
+	ResolverEndpointID string `json:"resolverEndpointId,omitempty"`
+
+	// The type of the rule, one of FORWARD, SYSTEM or RECURSIVE
+	//
+	// +optional
+	RuleType string `json:"ruleType"`
+
+	// Whether the rule is shared with or by another account
+	//
+	// +optional
+	ShareStatus string `json:"shareStatus,omitempty"`
+
+	// The status of the Resolver rule
+	//
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// The addresses queries are forwarded to
+	//
+	// +listType=atomic
+	// +optional
+	TargetIps []ResolverTargetAddress `json:"targetIps,omitempty"`
+}
+
+type ResolverTargetAddress struct {
+	// The IPv4 address queries are forwarded to
+	//
+	// +optional
+	IP string `json:"ip,omitempty"`
+
+	// The IPv6 address queries are forwarded to
+	//
+	// +optional
+	Ipv6 string `json:"ipv6,omitempty"`
+
+	// The port queries are forwarded to
+	//
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// The protocol queries are forwarded with
+	//
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+}
+
+type VpnConnection struct {
+	// The ID of the VPN connection
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The ID of the customer gateway at the on premises side of the
+	// connection
+	//
+	// +optional
+	CustomerGatewayID string `json:"customerGatewayId"`
+
+	// The name of the VPN connection
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The static routes associated with the VPN connection
+	//
+	// +listType=atomic
+	// +optional
+	Routes []string `json:"routes,omitempty"`
+
+	// The state of the VPN connection
+	//
+	// +optional
+	State string `json:"state"`
+
+	// Does the VPN connection use static routes only rather than BGP
+	//
+	// +optional
+	StaticRoutesOnly bool `json:"staticRoutesOnly"`
+
+	// The tunnels of the VPN connection
+	//
+	// +listType=atomic
+	// +optional
+	Tunnels []VpnTunnel `json:"tunnels,omitempty"`
+
+	// The type of the VPN connection
+	//
+	// +optional
+	Type string `json:"type"`
+
+	// The ID of the virtual private gateway at the AWS side of the connection
+	//
+	// +optional
+	VpnGatewayID string `json:"vpnGatewayId"`
+}
+
+type VpnTunnel struct {
+	// The outside IP address of the tunnel endpoint at the AWS side
+	//
+	// +optional
+	OutsideIPAddress string `json:"outsideIpAddress"`
+
+	// The status of the tunnel. One of UP or DOWN
+	//
+	// +optional
+	Status string `json:"status"`
+
+	// The status message of the tunnel
+	//
+	// +optional
+	StatusMessage string `json:"statusMessage,omitempty"`
+}
+
+type VpnGateway struct {
+	// The ID of the virtual private gateway
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The private ASN of the AWS side of the BGP session
+	//
+	// +optional
+	AmazonSideAsn int64 `json:"amazonSideAsn"`
+
+	// The availability zone the virtual private gateway is located in
+	//
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// The name of the virtual private gateway
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The state of the virtual private gateway
+	//
+	// +optional
+	State string `json:"state"`
+
+	// The type of VPN connection the virtual private gateway supports
+	//
+	// +optional
+	Type string `json:"type"`
+}
+
+type VpcEndpoint struct {
+	// The ID of the VPC endpoint
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The DNS entries for the VPC endpoint
+	//
+	// +listType=atomic
+	// +optional
+	DnsEntries []VpcEndpointDnsEntry `json:"dnsEntries,omitempty"`
+
+	// The IDs of the route tables associated with a gateway endpoint
+	//
+	// +listType=atomic
+	// +optional
+	RouteTableIDs []string `json:"routeTableIds,omitempty"`
+
+	// A map of security groups associated with the endpoint network
+	// interfaces
+	//
+	// +mapType=atomic
+	// +optional
+	SecurityGroups map[string]string `json:"securityGroups,omitempty"`
+
+	// The state of the VPC endpoint
+	//
+	// +optional
+	State string `json:"state"`
+
+	// The IDs of the subnets the endpoint network interfaces are placed in
+	//
+	// +listType=atomic
+	// +optional
+	SubnetIDs []string `json:"subnetIds,omitempty"`
+
+	// The type of the VPC endpoint. One of Gateway, Interface or
+	// GatewayLoadBalancer
+	//
+	// +optional
+	Type string `json:"type"`
+}
+
+type VpcEndpointDnsEntry struct {
+	// The DNS name
+	//
+	// +optional
+	DnsName string `json:"dnsName"`
+
+	// The ID of the private hosted zone
+	//
+	// +optional
+	HostedZoneID string `json:"hostedZoneId"`
+}
+
+type NatGateway struct {
+	// The ID of the NAT gateway
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The IP addresses and allocations assigned to the NAT gateway
+	//
+	// +listType=atomic
+	// +optional
+	Addresses []NatGatewayAddress `json:"addresses"`
+
+	// The availability zone the NAT gateway is located in
+	//
+	// +optional
+	AvailabilityZone string `json:"availabilityZone"`
+
+	// The connectivity type of the NAT gateway. One of public or private
+	//
+	// +optional
+	ConnectivityType string `json:"connectivityType"`
+
+	// The state of the NAT gateway
+	//
+	// +optional
+	State string `json:"state"`
+
+	// The ID of the subnet the NAT gateway is located in
+	//
+	// +optional
+	SubnetID string `json:"subnetId"`
+}
+
+type NatGatewayAddress struct {
+	// The allocation ID of the elastic IP address. Only set for public NAT
+	// gateways
+	//
+	// +optional
+	AllocationID string `json:"allocationId,omitempty"`
+
+	// Is this the primary address of the NAT gateway
+	//
+	// +optional
+	IsPrimary bool `json:"isPrimary"`
+
+	// The ID of the network interface the address is assigned to
+	//
+	// +optional
+	NetworkInterfaceID string `json:"networkInterfaceId"`
+
+	// The private IP address
+	//
+	// +optional
+	PrivateIP string `json:"privateIp"`
+
+	// The elastic IP address. Only set for public NAT gateways
+	//
+	// +optional
+	PublicIP string `json:"publicIp,omitempty"`
+}
+
+type NetworkAcl struct {
+	// The ID of the network ACL
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The egress rules of the network ACL
+	//
+	// +listType=atomic
+	// +optional
+	Egress []NetworkAclEntry `json:"egress"`
+
+	// The ingress rules of the network ACL
+	//
+	// +listType=atomic
+	// +optional
+	Ingress []NetworkAclEntry `json:"ingress"`
+
+	// Is this the default network ACL for the VPC
+	//
+	// +optional
+	IsDefault bool `json:"isDefault"`
+
+	// The name of the network ACL
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The IDs of the subnets associated with the network ACL
+	//
+	// +listType=atomic
+	// +optional
+	SubnetIDs []string `json:"subnetIds"`
 }
 
-// StatusRouteTables is a map of route tables and their status
-//
-// +mapType=atomic
-type StatusRouteTables map[string]StatusRouteTableDetails
+type NetworkAclEntry struct {
+	// The IPv4 cidr block the rule applies to
+	//
+	// +optional
+	CidrBlock string `json:"cidrBlock,omitempty"`
 
-type StatusRouteTableDetails struct {
-	// The ID of the route table
+	// The first port in the range the rule applies to
 	//
-	// +required
+	// +optional
+	FromPort int32 `json:"fromPort,omitempty"`
+
+	// The ICMP code the rule applies to
+	//
+	// +optional
+	IcmpCode *int32 `json:"icmpCode,omitempty"`
+
+	// The ICMP type the rule applies to
+	//
+	// +optional
+	IcmpType *int32 `json:"icmpType,omitempty"`
+
+	// The IPv6 cidr block the rule applies to
+	//
+	// +optional
+	Ipv6CidrBlock string `json:"ipv6CidrBlock,omitempty"`
+
+	// The protocol number the rule applies to. -1 means all protocols
+	//
+	// +optional
+	Protocol string `json:"protocol"`
+
+	// Whether to allow or deny matching traffic
+	//
+	// +optional
+	RuleAction string `json:"ruleAction"`
+
+	// The rule number. Rules are evaluated in ascending order
+	//
+	// +optional
+	RuleNumber int32 `json:"ruleNumber"`
+
+	// The last port in the range the rule applies to
+	//
+	// +optional
+	ToPort int32 `json:"toPort,omitempty"`
+}
+
+type PeeringConnection struct {
+	// The ID of the VPC peering connection
+	//
+	// +optional
 	ID string `json:"id"`
+
+	// The ARN of the VPC peering connection
+	//
+	// +optional
+	ARN string `json:"arn"`
+
+	// The accepter side of the VPC peering connection
+	//
+	// +optional
+	Accepter *PeeringConnectionVpc `json:"accepter,omitempty"`
+
+	// The requester side of the VPC peering connection
+	//
+	// +optional
+	Requester *PeeringConnectionVpc `json:"requester,omitempty"`
+
+	// The side of the peering connection the discovered VPC is on. One of
+	// requester or accepter
+	//
+	// +optional
+	Side string `json:"side,omitempty"`
+
+	// The status code of the VPC peering connection
+	//
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// The status message of the VPC peering connection
+	//
+	// +optional
+	StatusMessage string `json:"statusMessage,omitempty"`
 }
 
-// Vpc holds VPC information
-//
-// +structType=granular
-type AwsVpc struct {
-	// A list of additional VPC CIDR blocks defined in this VPC
-	// +listType=atomic
+type PeeringConnectionVpc struct {
+	// Can the VPC resolve public DNS hostnames of the remote VPC to private
+	// IP addresses
+	//
 	// +optional
-	AdditionalCidrBlocks []string `json:"additionalCidrBlocks,omitempty"`
+	AllowDnsResolutionFromRemoteVpc bool `json:"allowDnsResolutionFromRemoteVpc"`
 
-	// The Ipv4 cidr block defined for this VPC
+	// The primary IPv4 cidr block of the VPC
+	//
 	// +optional
 	CidrBlock string `json:"cidrBlock,omitempty"`
 
-	// ID The VPC ID
-	// +kubebuilder:validation:Required
-	// +required
-	ID string `json:"id,omitempty"`
+	// All IPv4 cidr blocks of the VPC that are part of the peering
+	//
+	// +listType=atomic
+	// +optional
+	CidrBlocks []string `json:"cidrBlocks,omitempty"`
 
-	// The internet gateway defined in this VPC
+	// The IPv6 cidr blocks of the VPC that are part of the peering
+	//
+	// +listType=atomic
 	// +optional
-	InternetGateway string `json:"internetGateway,omitempty"`
+	Ipv6CidrBlocks []string `json:"ipv6CidrBlocks,omitempty"`
 
-	// A map of NAT gateways defined in this VPC
-	// +mapType=atomic
+	// The account ID owning the VPC
+	//
 	// +optional
-	NatGateways map[string]string `json:"natGateways,omitempty"`
+	OwnerID string `json:"ownerId"`
 
-	// The owner of the current VPC
+	// The region the VPC is located in
+	//
 	// +optional
-	Owner string `json:"owner,omitempty"`
+	Region string `json:"region"`
 
-	// The provider config used to look up this VPC
+	// The ID of the VPC
+	//
 	// +optional
-	ProviderConfig string `json:"providerConfig,omitempty"`
+	VpcID string `json:"vpcId"`
+}
 
-	// A map of private subnets defined in this VPC
+type SecurityGroup struct {
+	// The ID of the security group
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The description of the security group
+	//
+	// +optional
+	Description string `json:"description"`
+
+	// The egress rules of the security group
+	//
 	// +listType=atomic
 	// +optional
-	PrivateSubnets []StatusSubnets `json:"privateSubnets,omitempty"`
+	Egress []SecurityGroupRule `json:"egress"`
 
-	// A list of maps of public subnets defined in this VPC
+	// The ingress rules of the security group
+	//
 	// +listType=atomic
 	// +optional
-	PublicSubnets []StatusSubnets `json:"publicSubnets,omitempty"`
+	Ingress []SecurityGroupRule `json:"ingress"`
 
-	// A map of private route tables defined in this VPC
+	// The tags assigned to the security group
+	//
+	// +mapType=atomic
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+type SecurityGroupRule struct {
+	// The IPv4 cidr blocks the rule applies to
+	//
 	// +listType=atomic
 	// +optional
-	PrivateRouteTables []StatusRouteTables `json:"privateRouteTables,omitempty"`
+	CidrBlocks []string `json:"cidrBlocks,omitempty"`
 
-	// A map of public route tables defined in this VPC
+	// The first port in the range the rule applies to
+	//
+	// +optional
+	FromPort *int32 `json:"fromPort,omitempty"`
+
+	// The IPv6 cidr blocks the rule applies to
+	//
 	// +listType=atomic
 	// +optional
-	PublicRouteTables []StatusRouteTables `json:"publicRouteTables,omitempty"`
+	Ipv6CidrBlocks []string `json:"ipv6CidrBlocks,omitempty"`
 
-	// The region this VPC is located in
+	// The IDs of the prefix lists the rule applies to
+	//
+	// +listType=atomic
 	// +optional
-	Region string `json:"region,omitempty"`
+	PrefixListIDs []string `json:"prefixListIds,omitempty"`
 
-	// A map of security groups defined in this VPC
-	// +mapType=atomic
+	// The IP protocol name or number. -1 means all protocols
+	//
 	// +optional
-	SecurityGroups map[string]string `json:"securityGroups,omitempty"`
+	Protocol string `json:"protocol"`
 
-	// A map of transit gateways defined in this VPC
-	// +mapType=atomic
+	// The security groups the rule applies to
+	//
+	// +listType=atomic
 	// +optional
-	TransitGateways map[string]TransitGateway `json:"transitGateways,omitempty"`
+	SecurityGroups []SecurityGroupReference `json:"securityGroups,omitempty"`
 
-	// A map of VPC peering connections defined in this VPC
-	// +mapType=atomic
+	// The last port in the range the rule applies to
+	//
 	// +optional
-	VpcPeeringConnections map[string]PeeringConnection `json:"vpcPeeringConnections,omitempty"`
+	ToPort *int32 `json:"toPort,omitempty"`
 }
 
-type PeeringConnection struct {
-	// The ID of the VPC peering connection
+type SecurityGroupReference struct {
+	// The ID of the referenced security group
 	//
 	// +optional
 	ID string `json:"id"`
 
-	// The ARN of the VPC peering connection
+	// The account ID owning the referenced security group
 	//
 	// +optional
-	ARN string `json:"arn"`
+	OwnerID string `json:"ownerId,omitempty"`
+
+	// The ID of the VPC the referenced security group is in
+	//
+	// +optional
+	VpcID string `json:"vpcId,omitempty"`
 }
 
 type TransitGateway struct {
@@ -175,6 +1150,12 @@ type TransitGatewayAttachment struct {
 	// +optional
 	ResourceID string `json:"resourceId"`
 
+	// The accepter and requester transit gateways of a peering attachment.
+	// Only set when the attachment type is peering
+	//
+	// +optional
+	Peering *TransitGatewayPeering `json:"peering,omitempty"`
+
 	// The associated route table ID
 	//
 	// +optional
@@ -186,6 +1167,45 @@ type TransitGatewayAttachment struct {
 	Type string `json:"type"`
 }
 
+type TransitGatewayPeering struct {
+	// The accepter side of the peering
+	//
+	// +optional
+	Accepter *TransitGatewayPeeringSide `json:"accepter,omitempty"`
+
+	// The requester side of the peering
+	//
+	// +optional
+	Requester *TransitGatewayPeeringSide `json:"requester,omitempty"`
+
+	// The state of the peering attachment
+	//
+	// +optional
+	State string `json:"state"`
+
+	// The status message of the peering attachment
+	//
+	// +optional
+	StatusMessage string `json:"statusMessage,omitempty"`
+}
+
+type TransitGatewayPeeringSide struct {
+	// The account ID owning the transit gateway
+	//
+	// +optional
+	OwnerID string `json:"ownerId"`
+
+	// The region the transit gateway is located in
+	//
+	// +optional
+	Region string `json:"region"`
+
+	// The ID of the transit gateway
+	//
+	// +optional
+	TransitGatewayID string `json:"transitGatewayId"`
+}
+
 type TransitGatewayRouteTable struct {
 	// The ID of the transit gateway route table
 	//
@@ -201,6 +1221,82 @@ type TransitGatewayRouteTable struct {
 	//
 	// +optional
 	DefaultPropagation bool `json:"defaultPropagation"`
+
+	// The attachments associated with this route table
+	//
+	// +listType=atomic
+	// +optional
+	Associations []TransitGatewayRouteTableAttachment `json:"associations,omitempty"`
+
+	// The attachments propagating routes to this route table
+	//
+	// +listType=atomic
+	// +optional
+	Propagations []TransitGatewayRouteTableAttachment `json:"propagations,omitempty"`
+
+	// The routes defined in this route table keyed by their destination CIDR
+	// block or prefix list. Routes with neither are keyed as `route-<n>`
+	//
+	// +mapType=atomic
+	// +optional
+	Routes map[string]TransitGatewayRoute `json:"routes,omitempty"`
+
+	// Does the route table hold more routes than could be returned. When
+	// set, `routes` only contains part of the routes in the table
+	//
+	// +optional
+	RoutesIncomplete bool `json:"routesIncomplete"`
+}
+
+type TransitGatewayRoute struct {
+	// The attachments the route sends traffic to
+	//
+	// +listType=atomic
+	// +optional
+	Attachments []TransitGatewayRouteTableAttachment `json:"attachments"`
+
+	// The destination CIDR block of the route
+	//
+	// +optional
+	DestinationCidrBlock string `json:"destinationCidrBlock,omitempty"`
+
+	// The destination prefix list ID of the route
+	//
+	// +optional
+	PrefixListID string `json:"prefixListId,omitempty"`
+
+	// The state of the route. One of active, blackhole, deleting, deleted or
+	// pending
+	//
+	// +optional
+	State string `json:"state"`
+
+	// The type of the route. One of static or propagated
+	//
+	// +optional
+	Type string `json:"type"`
+}
+
+type TransitGatewayRouteTableAttachment struct {
+	// The ID of the transit gateway attachment
+	//
+	// +optional
+	ID string `json:"id"`
+
+	// The ID of the resource that the transit gateway is attached to
+	//
+	// +optional
+	ResourceID string `json:"resourceId"`
+
+	// The state of the association or propagation
+	//
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// The type of the transit gateway attachment
+	//
+	// +optional
+	Type string `json:"type"`
 }
 
 // AwsSubnet is an object that holds information about a subnet defined in AWS
@@ -218,10 +1314,41 @@ type AwsSubnet struct {
 	// +optional
 	AvailabilityZone string `json:"availabilityZone"`
 
+	// The ID of the availability zone this subnet is located in
+	// +optional
+	AvailabilityZoneID string `json:"availabilityZoneId"`
+
+	// The type of zone this subnet is located in
+	// +optional
+	AvailabilityZoneType string `json:"availabilityZoneType"`
+
+	// The number of unused IPv4 addresses in the subnet
+	// +optional
+	AvailableIpAddressCount int32 `json:"availableIpAddressCount"`
+
+	// The IPv4 address capacity of the subnet. Not set for IPv6 only subnets
+	// +optional
+	Capacity *SubnetCapacity `json:"capacity,omitempty"`
+
 	// The Ipv4 cidr block defined for this subnet
 	// +optional
 	CidrBlock string `json:"cidrBlock"`
 
+	// The CIDR reservations defined in this subnet. Only populated when CIDR
+	// reservation discovery is enabled
+	// +listType=atomic
+	// +optional
+	CidrReservations []SubnetCidrReservation `json:"cidrReservations,omitempty"`
+
+	// Is this the default subnet for its availability zone
+	// +optional
+	DefaultForAz bool `json:"defaultForAz"`
+
+	// Does this subnet use the VPC main route table without an explicit
+	// association
+	// +optional
+	ImplicitRouteTableAssociation bool `json:"implicitRouteTableAssociation"`
+
 	// Is this subnet enabled for IPv6
 	// +optional
 	IsIpv6 bool `json:"isIpV6"`
@@ -230,6 +1357,18 @@ type AwsSubnet struct {
 	// +optional
 	Ipv6CidrBlock string `json:"ipv6CidrBlock"`
 
+	// Is this an IPv6 only subnet without an IPv4 CIDR block
+	// +optional
+	Ipv6Native bool `json:"ipv6Native"`
+
+	// The egress only internet gateway associated with this subnet
+	// +optional
+	EgressOnlyInternetGateway string `json:"egressOnlyInternetGateway"`
+
+	// The virtual private gateway routed to from this subnet
+	// +optional
+	VpnGateway string `json:"vpnGateway"`
+
 	// Is this a public subnet. Determined by validating an internet gateway on
 	// the subnet route tables
 	// +optional
@@ -250,7 +1389,7 @@ type AwsSubnet struct {
 	// A map of NAT gateways associated with this subnet
 	// +mapType=granular
 	// +optional
-	NatGateways map[string]string `json:"natGateways"`
+	NatGateways map[string]NatGateway `json:"natGateways"`
 
 	// The tag value to group subnets by
 	// +optional
@@ -279,6 +1418,10 @@ type AwsRouteTable struct {
 	// +kubebuilder:validation:Required
 	ID string `json:"id"`
 
+	// Is this route table implicitly associated as the VPC main route table
+	// +optional
+	IsImplicit bool `json:"isImplicit"`
+
 	// Is this a public route table. Determined by validating the
 	// existence of an internet gateway
 	// +optional
@@ -288,6 +1431,12 @@ type AwsRouteTable struct {
 	// +optional
 	Name string `json:"name"`
 
+	// The IDs of the virtual private gateways propagating routes to this
+	// route table
+	// +listType=atomic
+	// +optional
+	PropagatingVpnGateways []string `json:"propagatingVpnGateways"`
+
 	// The routes defined in this route table
 	// +mapType=granular
 	Routes map[string]AwsRoute `json:"routes"`
@@ -300,7 +1449,8 @@ type AwsRouteTable struct {
 // AwsRoute is an object that holds information about a route defined in AWS
 // +mapType=granular
 type AwsRoute struct {
-	// ID The route ID
+	// ID The route ID. This is the destination CIDR block, IPv6 CIDR block or
+	// prefix list ID of the route
 	// +kubebuilder:validation:Required
 	ID string `json:"id"`
 
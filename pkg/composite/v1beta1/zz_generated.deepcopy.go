@@ -8,6 +8,21 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailabilityZone) DeepCopyInto(out *AvailabilityZone) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailabilityZone.
+func (in *AvailabilityZone) DeepCopy() *AvailabilityZone {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailabilityZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Aws) DeepCopyInto(out *Aws) {
 	*out = *in
@@ -78,6 +93,11 @@ func (in *AwsRouteTable) DeepCopyInto(out *AwsRouteTable) {
 		*out = make([]AwsAssociation, len(*in))
 		copy(*out, *in)
 	}
+	if in.PropagatingVpnGateways != nil {
+		in, out := &in.PropagatingVpnGateways, &out.PropagatingVpnGateways
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Routes != nil {
 		in, out := &in.Routes, &out.Routes
 		*out = make(map[string]AwsRoute, len(*in))
@@ -100,6 +120,16 @@ func (in *AwsRouteTable) DeepCopy() *AwsRouteTable {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AwsSubnet) DeepCopyInto(out *AwsSubnet) {
 	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(SubnetCapacity)
+		**out = **in
+	}
+	if in.CidrReservations != nil {
+		in, out := &in.CidrReservations, &out.CidrReservations
+		*out = make([]SubnetCidrReservation, len(*in))
+		copy(*out, *in)
+	}
 	if in.MapPublicIPOnLaunch != nil {
 		in, out := &in.MapPublicIPOnLaunch, &out.MapPublicIPOnLaunch
 		*out = new(bool)
@@ -114,9 +144,9 @@ func (in *AwsSubnet) DeepCopyInto(out *AwsSubnet) {
 	}
 	if in.NatGateways != nil {
 		in, out := &in.NatGateways, &out.NatGateways
-		*out = make(map[string]string, len(*in))
+		*out = make(map[string]NatGateway, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	if in.TransitGateways != nil {
@@ -130,7 +160,7 @@ func (in *AwsSubnet) DeepCopyInto(out *AwsSubnet) {
 		in, out := &in.VpcPeeringConnections, &out.VpcPeeringConnections
 		*out = make(map[string]PeeringConnection, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 }
@@ -153,11 +183,62 @@ func (in *AwsVpc) DeepCopyInto(out *AwsVpc) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AvailabilityZones != nil {
+		in, out := &in.AvailabilityZones, &out.AvailabilityZones
+		*out = make([]AvailabilityZone, len(*in))
+		copy(*out, *in)
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(SubnetCapacity)
+		**out = **in
+	}
+	if in.CustomerGateways != nil {
+		in, out := &in.CustomerGateways, &out.CustomerGateways
+		*out = make(map[string]CustomerGateway, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DirectConnectGatewayAssociations != nil {
+		in, out := &in.DirectConnectGatewayAssociations, &out.DirectConnectGatewayAssociations
+		*out = make(map[string]DirectConnectGatewayAssociation, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.DhcpOptions != nil {
+		in, out := &in.DhcpOptions, &out.DhcpOptions
+		*out = new(DhcpOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(VpcDns)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ipv6CidrBlocks != nil {
+		in, out := &in.Ipv6CidrBlocks, &out.Ipv6CidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.NatGateways != nil {
 		in, out := &in.NatGateways, &out.NatGateways
-		*out = make(map[string]string, len(*in))
+		*out = make(map[string]NatGateway, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.NetworkAcls != nil {
+		in, out := &in.NetworkAcls, &out.NetworkAcls
+		*out = make(map[string]NetworkAcl, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	if in.PrivateSubnets != nil {
@@ -168,7 +249,7 @@ func (in *AwsVpc) DeepCopyInto(out *AwsVpc) {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = make(StatusSubnets, len(*in))
 				for key, val := range *in {
-					(*out)[key] = val
+					(*out)[key] = *val.DeepCopy()
 				}
 			}
 		}
@@ -181,7 +262,7 @@ func (in *AwsVpc) DeepCopyInto(out *AwsVpc) {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = make(StatusSubnets, len(*in))
 				for key, val := range *in {
-					(*out)[key] = val
+					(*out)[key] = *val.DeepCopy()
 				}
 			}
 		}
@@ -194,7 +275,7 @@ func (in *AwsVpc) DeepCopyInto(out *AwsVpc) {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = make(StatusRouteTables, len(*in))
 				for key, val := range *in {
-					(*out)[key] = val
+					(*out)[key] = *val.DeepCopy()
 				}
 			}
 		}
@@ -207,7 +288,7 @@ func (in *AwsVpc) DeepCopyInto(out *AwsVpc) {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = make(StatusRouteTables, len(*in))
 				for key, val := range *in {
-					(*out)[key] = val
+					(*out)[key] = *val.DeepCopy()
 				}
 			}
 		}
@@ -219,6 +300,18 @@ func (in *AwsVpc) DeepCopyInto(out *AwsVpc) {
 			(*out)[key] = val
 		}
 	}
+	if in.SecurityGroupDetails != nil {
+		in, out := &in.SecurityGroupDetails, &out.SecurityGroupDetails
+		*out = make(map[string]SecurityGroup, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.SubnetSetCapacity != nil {
+		in, out := &in.SubnetSetCapacity, &out.SubnetSetCapacity
+		*out = make([]SubnetCapacity, len(*in))
+		copy(*out, *in)
+	}
 	if in.TransitGateways != nil {
 		in, out := &in.TransitGateways, &out.TransitGateways
 		*out = make(map[string]TransitGateway, len(*in))
@@ -226,13 +319,43 @@ func (in *AwsVpc) DeepCopyInto(out *AwsVpc) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.VpcEndpoints != nil {
+		in, out := &in.VpcEndpoints, &out.VpcEndpoints
+		*out = make(map[string][]VpcEndpoint, len(*in))
+		for key, val := range *in {
+			var outVal []VpcEndpoint
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]VpcEndpoint, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.VpcPeeringConnections != nil {
 		in, out := &in.VpcPeeringConnections, &out.VpcPeeringConnections
 		*out = make(map[string]PeeringConnection, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.VpnConnections != nil {
+		in, out := &in.VpnConnections, &out.VpnConnections
+		*out = make(map[string]VpnConnection, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.VpnGateway != nil {
+		in, out := &in.VpnGateway, &out.VpnGateway
+		*out = new(VpnGateway)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsVpc.
@@ -245,9 +368,178 @@ func (in *AwsVpc) DeepCopy() *AwsVpc {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomerGateway) DeepCopyInto(out *CustomerGateway) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomerGateway.
+func (in *CustomerGateway) DeepCopy() *CustomerGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomerGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DhcpOptions) DeepCopyInto(out *DhcpOptions) {
+	*out = *in
+	if in.DomainNameServers != nil {
+		in, out := &in.DomainNameServers, &out.DomainNameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetbiosNameServers != nil {
+		in, out := &in.NetbiosNameServers, &out.NetbiosNameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NtpServers != nil {
+		in, out := &in.NtpServers, &out.NtpServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DhcpOptions.
+func (in *DhcpOptions) DeepCopy() *DhcpOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DhcpOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DirectConnectGatewayAssociation) DeepCopyInto(out *DirectConnectGatewayAssociation) {
+	*out = *in
+	if in.AllowedPrefixes != nil {
+		in, out := &in.AllowedPrefixes, &out.AllowedPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectConnectGatewayAssociation.
+func (in *DirectConnectGatewayAssociation) DeepCopy() *DirectConnectGatewayAssociation {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectConnectGatewayAssociation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatGateway) DeepCopyInto(out *NatGateway) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]NatGatewayAddress, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatGateway.
+func (in *NatGateway) DeepCopy() *NatGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(NatGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatGatewayAddress) DeepCopyInto(out *NatGatewayAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatGatewayAddress.
+func (in *NatGatewayAddress) DeepCopy() *NatGatewayAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(NatGatewayAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkAcl) DeepCopyInto(out *NetworkAcl) {
+	*out = *in
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]NetworkAclEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]NetworkAclEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkAcl.
+func (in *NetworkAcl) DeepCopy() *NetworkAcl {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAcl)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkAclEntry) DeepCopyInto(out *NetworkAclEntry) {
+	*out = *in
+	if in.IcmpCode != nil {
+		in, out := &in.IcmpCode, &out.IcmpCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IcmpType != nil {
+		in, out := &in.IcmpType, &out.IcmpType
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkAclEntry.
+func (in *NetworkAclEntry) DeepCopy() *NetworkAclEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAclEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PeeringConnection) DeepCopyInto(out *PeeringConnection) {
 	*out = *in
+	if in.Accepter != nil {
+		in, out := &in.Accepter, &out.Accepter
+		*out = new(PeeringConnectionVpc)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Requester != nil {
+		in, out := &in.Requester, &out.Requester
+		*out = new(PeeringConnectionVpc)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringConnection.
@@ -260,9 +552,192 @@ func (in *PeeringConnection) DeepCopy() *PeeringConnection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringConnectionVpc) DeepCopyInto(out *PeeringConnectionVpc) {
+	*out = *in
+	if in.CidrBlocks != nil {
+		in, out := &in.CidrBlocks, &out.CidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ipv6CidrBlocks != nil {
+		in, out := &in.Ipv6CidrBlocks, &out.Ipv6CidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringConnectionVpc.
+func (in *PeeringConnectionVpc) DeepCopy() *PeeringConnectionVpc {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringConnectionVpc)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateHostedZone) DeepCopyInto(out *PrivateHostedZone) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateHostedZone.
+func (in *PrivateHostedZone) DeepCopy() *PrivateHostedZone {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateHostedZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolverRule) DeepCopyInto(out *ResolverRule) {
+	*out = *in
+	if in.TargetIps != nil {
+		in, out := &in.TargetIps, &out.TargetIps
+		*out = make([]ResolverTargetAddress, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolverRule.
+func (in *ResolverRule) DeepCopy() *ResolverRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolverRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolverTargetAddress) DeepCopyInto(out *ResolverTargetAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolverTargetAddress.
+func (in *ResolverTargetAddress) DeepCopy() *ResolverTargetAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolverTargetAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
+	*out = *in
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]SecurityGroupRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]SecurityGroupRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroup.
+func (in *SecurityGroup) DeepCopy() *SecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupReference) DeepCopyInto(out *SecurityGroupReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupReference.
+func (in *SecurityGroupReference) DeepCopy() *SecurityGroupReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupRule) DeepCopyInto(out *SecurityGroupRule) {
+	*out = *in
+	if in.CidrBlocks != nil {
+		in, out := &in.CidrBlocks, &out.CidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromPort != nil {
+		in, out := &in.FromPort, &out.FromPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Ipv6CidrBlocks != nil {
+		in, out := &in.Ipv6CidrBlocks, &out.Ipv6CidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrefixListIDs != nil {
+		in, out := &in.PrefixListIDs, &out.PrefixListIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]SecurityGroupReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToPort != nil {
+		in, out := &in.ToPort, &out.ToPort
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRule.
+func (in *SecurityGroupRule) DeepCopy() *SecurityGroupRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusRouteTableDetails) DeepCopyInto(out *StatusRouteTableDetails) {
 	*out = *in
+	if in.PropagatingVpnGateways != nil {
+		in, out := &in.PropagatingVpnGateways, &out.PropagatingVpnGateways
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make(map[string]AwsRoute, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusRouteTableDetails.
@@ -281,7 +756,7 @@ func (in StatusRouteTables) DeepCopyInto(out *StatusRouteTables) {
 		in := &in
 		*out = make(StatusRouteTables, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 }
@@ -299,6 +774,16 @@ func (in StatusRouteTables) DeepCopy() StatusRouteTables {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusSubnetDetails) DeepCopyInto(out *StatusSubnetDetails) {
 	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(SubnetCapacity)
+		**out = **in
+	}
+	if in.CidrReservations != nil {
+		in, out := &in.CidrReservations, &out.CidrReservations
+		*out = make([]SubnetCidrReservation, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusSubnetDetails.
@@ -317,7 +802,7 @@ func (in StatusSubnets) DeepCopyInto(out *StatusSubnets) {
 		in := &in
 		*out = make(StatusSubnets, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 }
@@ -332,6 +817,36 @@ func (in StatusSubnets) DeepCopy() StatusSubnets {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetCapacity) DeepCopyInto(out *SubnetCapacity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetCapacity.
+func (in *SubnetCapacity) DeepCopy() *SubnetCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetCidrReservation) DeepCopyInto(out *SubnetCidrReservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetCidrReservation.
+func (in *SubnetCidrReservation) DeepCopy() *SubnetCidrReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetCidrReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransitGateway) DeepCopyInto(out *TransitGateway) {
 	*out = *in
@@ -339,14 +854,14 @@ func (in *TransitGateway) DeepCopyInto(out *TransitGateway) {
 		in, out := &in.Attachments, &out.Attachments
 		*out = make(map[string]TransitGatewayAttachment, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	if in.RouteTables != nil {
 		in, out := &in.RouteTables, &out.RouteTables
 		*out = make(map[string]TransitGatewayRouteTable, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 }
@@ -364,6 +879,11 @@ func (in *TransitGateway) DeepCopy() *TransitGateway {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransitGatewayAttachment) DeepCopyInto(out *TransitGatewayAttachment) {
 	*out = *in
+	if in.Peering != nil {
+		in, out := &in.Peering, &out.Peering
+		*out = new(TransitGatewayPeering)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayAttachment.
@@ -376,9 +896,86 @@ func (in *TransitGatewayAttachment) DeepCopy() *TransitGatewayAttachment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitGatewayPeering) DeepCopyInto(out *TransitGatewayPeering) {
+	*out = *in
+	if in.Accepter != nil {
+		in, out := &in.Accepter, &out.Accepter
+		*out = new(TransitGatewayPeeringSide)
+		**out = **in
+	}
+	if in.Requester != nil {
+		in, out := &in.Requester, &out.Requester
+		*out = new(TransitGatewayPeeringSide)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayPeering.
+func (in *TransitGatewayPeering) DeepCopy() *TransitGatewayPeering {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitGatewayPeering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitGatewayPeeringSide) DeepCopyInto(out *TransitGatewayPeeringSide) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayPeeringSide.
+func (in *TransitGatewayPeeringSide) DeepCopy() *TransitGatewayPeeringSide {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitGatewayPeeringSide)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitGatewayRoute) DeepCopyInto(out *TransitGatewayRoute) {
+	*out = *in
+	if in.Attachments != nil {
+		in, out := &in.Attachments, &out.Attachments
+		*out = make([]TransitGatewayRouteTableAttachment, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayRoute.
+func (in *TransitGatewayRoute) DeepCopy() *TransitGatewayRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitGatewayRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransitGatewayRouteTable) DeepCopyInto(out *TransitGatewayRouteTable) {
 	*out = *in
+	if in.Associations != nil {
+		in, out := &in.Associations, &out.Associations
+		*out = make([]TransitGatewayRouteTableAttachment, len(*in))
+		copy(*out, *in)
+	}
+	if in.Propagations != nil {
+		in, out := &in.Propagations, &out.Propagations
+		*out = make([]TransitGatewayRouteTableAttachment, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make(map[string]TransitGatewayRoute, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayRouteTable.
@@ -390,3 +987,154 @@ func (in *TransitGatewayRouteTable) DeepCopy() *TransitGatewayRouteTable {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitGatewayRouteTableAttachment) DeepCopyInto(out *TransitGatewayRouteTableAttachment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayRouteTableAttachment.
+func (in *TransitGatewayRouteTableAttachment) DeepCopy() *TransitGatewayRouteTableAttachment {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitGatewayRouteTableAttachment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcDns) DeepCopyInto(out *VpcDns) {
+	*out = *in
+	if in.PrivateHostedZones != nil {
+		in, out := &in.PrivateHostedZones, &out.PrivateHostedZones
+		*out = make(map[string]PrivateHostedZone, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResolverRules != nil {
+		in, out := &in.ResolverRules, &out.ResolverRules
+		*out = make(map[string]ResolverRule, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDns.
+func (in *VpcDns) DeepCopy() *VpcDns {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcDns)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEndpoint) DeepCopyInto(out *VpcEndpoint) {
+	*out = *in
+	if in.DnsEntries != nil {
+		in, out := &in.DnsEntries, &out.DnsEntries
+		*out = make([]VpcEndpointDnsEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.RouteTableIDs != nil {
+		in, out := &in.RouteTableIDs, &out.RouteTableIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEndpoint.
+func (in *VpcEndpoint) DeepCopy() *VpcEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEndpointDnsEntry) DeepCopyInto(out *VpcEndpointDnsEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEndpointDnsEntry.
+func (in *VpcEndpointDnsEntry) DeepCopy() *VpcEndpointDnsEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEndpointDnsEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpnConnection) DeepCopyInto(out *VpnConnection) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tunnels != nil {
+		in, out := &in.Tunnels, &out.Tunnels
+		*out = make([]VpnTunnel, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpnConnection.
+func (in *VpnConnection) DeepCopy() *VpnConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(VpnConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpnGateway) DeepCopyInto(out *VpnGateway) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpnGateway.
+func (in *VpnGateway) DeepCopy() *VpnGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(VpnGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpnTunnel) DeepCopyInto(out *VpnTunnel) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpnTunnel.
+func (in *VpnTunnel) DeepCopy() *VpnTunnel {
+	if in == nil {
+		return nil
+	}
+	out := new(VpnTunnel)
+	in.DeepCopyInto(out)
+	return out
+}
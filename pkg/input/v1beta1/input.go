@@ -25,9 +25,26 @@ type Input struct {
 }
 
 type RemoteVpc struct {
-	// The VPC name
+	// The VPC name. This is the key the VPC is reported under and, unless one
+	// of ID, Tags or CidrBlock is given, the value of the Name tag to look up
 	Name string `json:"name"`
 
+	// The ID of the VPC to look up
+	//
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// A list of tags the VPC must carry
+	//
+	// +optional
+	Tags []TagFilter `json:"tags,omitempty"`
+
+	// A CIDR block associated with the VPC. May be the primary, a secondary
+	// or an IPv6 CIDR block
+	//
+	// +optional
+	CidrBlock string `json:"cidrBlock,omitempty"`
+
 	// GroupBy is an AWS tag name that is used to group subnet and route table
 	// results into logical "sets" of data
 	GroupBy string `json:"groupBy"`
@@ -37,11 +54,153 @@ type RemoteVpc struct {
 
 	// The VPC provider config
 	ProviderConfig string `json:"providerConfig"`
+
+	// Ambiguity decides how to proceed when more than one VPC matches. If not
+	// set, the ambiguity policy from the input spec is used
+	//
+	// +optional
+	Ambiguity *AmbiguityPolicy `json:"ambiguity,omitempty"`
+
+	// SecurityGroups controls how security groups are discovered for this
+	// VPC. If not set, the options from the input spec are used
+	//
+	// +optional
+	SecurityGroups *SecurityGroupOptions `json:"securityGroups,omitempty"`
+
+	// TransitGatewayAttachments controls which transit gateway attachments are
+	// reported for this VPC. If not set, the options from the input spec are
+	// used
+	//
+	// +optional
+	TransitGatewayAttachments *TransitGatewayAttachmentOptions `json:"transitGatewayAttachments,omitempty"`
+
+	// Discover enables optional lookups for this VPC. If not set, the options
+	// from the input spec are used
+	//
+	// +optional
+	Discover *DiscoveryOptions `json:"discover,omitempty"`
+}
+
+// DiscoveryOptions enables lookups which need additional API calls or
+// permissions beyond EC2 and are therefore disabled by default
+type DiscoveryOptions struct {
+	// DirectConnect looks up the Direct Connect gateway associations of the
+	// VPN gateway and transit gateways discovered for the VPC
+	//
+	// +optional
+	DirectConnect bool `json:"directConnect,omitempty"`
+
+	// DNS looks up the Route 53 private hosted zones and Resolver rules
+	// associated with the VPC
+	//
+	// +optional
+	DNS bool `json:"dns,omitempty"`
+
+	// SubnetCidrReservations looks up the CIDR reservations of every subnet
+	// in the VPC. This costs one additional API call per subnet
+	//
+	// +optional
+	SubnetCidrReservations bool `json:"subnetCidrReservations,omitempty"`
+}
+
+// TransitGatewayAttachmentOptions controls which transit gateway attachments
+// are reported. By default only the attachment of the discovered VPC is
+// reported
+type TransitGatewayAttachmentOptions struct {
+	// All reports every attachment on the transit gateway
+	//
+	// +optional
+	All bool `json:"all,omitempty"`
+
+	// Types additionally reports attachments of the given resource types, for
+	// example peering, vpn, direct-connect-gateway or connect
+	//
+	// +optional
+	Types []string `json:"types,omitempty"`
+}
+
+// SecurityGroupOptions controls how security groups are discovered
+type SecurityGroupOptions struct {
+	// Details reports the description, tags and rules of each security group
+	// in addition to the map of security group names to IDs
+	//
+	// +optional
+	Details bool `json:"details,omitempty"`
+
+	// Names restricts discovery to security groups whose name matches any of
+	// the given patterns. `*` matches any sequence of characters and `?` a
+	// single character, as in EC2 filters
+	//
+	// +optional
+	Names []string `json:"names,omitempty"`
+
+	// Tags restricts discovery to security groups carrying all of the given
+	// tags
+	//
+	// +optional
+	Tags []TagFilter `json:"tags,omitempty"`
+}
+
+const (
+	// AmbiguityPolicyError fails the lookup when more than one VPC matches
+	AmbiguityPolicyError = "error"
+
+	// AmbiguityPolicyNewest selects the VPC with the most recent timestamp tag
+	AmbiguityPolicyNewest = "newest"
+
+	// AmbiguityPolicyPreferTag selects the only VPC carrying a given tag
+	AmbiguityPolicyPreferTag = "preferTag"
+)
+
+// AmbiguityPolicy defines how a single VPC is selected when a lookup matches
+// more than one VPC
+type AmbiguityPolicy struct {
+	// Policy is the selection policy to apply
+	//
+	// +kubebuilder:validation:Enum=error;newest;preferTag
+	// +kubebuilder:default=error
+	// +optional
+	Policy string `json:"policy,omitempty"`
+
+	// Tag is the tag to prefer when the policy is preferTag
+	//
+	// +optional
+	Tag *TagFilter `json:"tag,omitempty"`
+
+	// TimestampTag is the name of a tag holding an RFC3339 creation timestamp.
+	// EC2 does not report when a VPC was created, so this is required when
+	// the policy is newest
+	//
+	// +optional
+	TimestampTag string `json:"timestampTag,omitempty"`
+}
+
+// TagFilter matches resources on a tag key and value
+type TagFilter struct {
+	// The tag key
+	Key string `json:"key"`
+
+	// The tag value. If empty, any resource carrying the tag key matches
+	//
+	// +optional
+	Value string `json:"value,omitempty"`
 }
 
 // Spec - Defines the spec given to this input type, providing the required,
 // and optional elements that may be defined
 type Spec struct {
+	// Ambiguity decides how to proceed when more than one VPC matches a lookup.
+	// By default the lookup fails
+	//
+	// +optional
+	Ambiguity *AmbiguityPolicy `json:"ambiguity,omitempty"`
+
+	// Discover enables optional lookups which need additional API calls or
+	// permissions. All optional lookups are disabled by default
+	//
+	// +optional
+	Discover *DiscoveryOptions `json:"discover,omitempty"`
+
 	// EnabledRef A path to a field on the claim that determines if this function
 	// is enabled in the current composition allowing for conditional execution
 	// of the function in complex compositions
@@ -77,6 +236,18 @@ type Spec struct {
 	// +required
 	RegionRef string `json:"regionRef"`
 
+	// SecurityGroups controls how security groups are discovered
+	//
+	// +optional
+	SecurityGroups *SecurityGroupOptions `json:"securityGroups,omitempty"`
+
+	// TransitGatewayAttachments controls which transit gateway attachments are
+	// reported. By default only the attachment of the discovered VPC is
+	// reported
+	//
+	// +optional
+	TransitGatewayAttachments *TransitGatewayAttachmentOptions `json:"transitGatewayAttachments,omitempty"`
+
 	// VpcName A path to the VPC name in the Claim
 	//
 	// +required
@@ -8,6 +8,41 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AmbiguityPolicy) DeepCopyInto(out *AmbiguityPolicy) {
+	*out = *in
+	if in.Tag != nil {
+		in, out := &in.Tag, &out.Tag
+		*out = new(TagFilter)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AmbiguityPolicy.
+func (in *AmbiguityPolicy) DeepCopy() *AmbiguityPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AmbiguityPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiscoveryOptions) DeepCopyInto(out *DiscoveryOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveryOptions.
+func (in *DiscoveryOptions) DeepCopy() *DiscoveryOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveryOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Input) DeepCopyInto(out *Input) {
 	*out = *in
@@ -16,7 +51,7 @@ func (in *Input) DeepCopyInto(out *Input) {
 	if in.Spec != nil {
 		in, out := &in.Spec, &out.Spec
 		*out = new(Spec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -41,6 +76,31 @@ func (in *Input) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteVpc) DeepCopyInto(out *RemoteVpc) {
 	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]TagFilter, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ambiguity != nil {
+		in, out := &in.Ambiguity, &out.Ambiguity
+		*out = new(AmbiguityPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = new(SecurityGroupOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransitGatewayAttachments != nil {
+		in, out := &in.TransitGatewayAttachments, &out.TransitGatewayAttachments
+		*out = new(TransitGatewayAttachmentOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Discover != nil {
+		in, out := &in.Discover, &out.Discover
+		*out = new(DiscoveryOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteVpc.
@@ -53,9 +113,54 @@ func (in *RemoteVpc) DeepCopy() *RemoteVpc {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupOptions) DeepCopyInto(out *SecurityGroupOptions) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]TagFilter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupOptions.
+func (in *SecurityGroupOptions) DeepCopy() *SecurityGroupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Spec) DeepCopyInto(out *Spec) {
 	*out = *in
+	if in.Ambiguity != nil {
+		in, out := &in.Ambiguity, &out.Ambiguity
+		*out = new(AmbiguityPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Discover != nil {
+		in, out := &in.Discover, &out.Discover
+		*out = new(DiscoveryOptions)
+		**out = **in
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = new(SecurityGroupOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransitGatewayAttachments != nil {
+		in, out := &in.TransitGatewayAttachments, &out.TransitGatewayAttachments
+		*out = new(TransitGatewayAttachmentOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Spec.
@@ -67,3 +172,38 @@ func (in *Spec) DeepCopy() *Spec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TagFilter) DeepCopyInto(out *TagFilter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagFilter.
+func (in *TagFilter) DeepCopy() *TagFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(TagFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitGatewayAttachmentOptions) DeepCopyInto(out *TransitGatewayAttachmentOptions) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayAttachmentOptions.
+func (in *TransitGatewayAttachmentOptions) DeepCopy() *TransitGatewayAttachmentOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitGatewayAttachmentOptions)
+	in.DeepCopyInto(out)
+	return out
+}
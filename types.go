@@ -9,4 +9,15 @@ import (
 type Function struct {
 	fnv1beta1.UnimplementedFunctionRunnerServiceServer
 	log logging.Logger
+
+	// maxConcurrency limits the number of VPC lookups run in parallel
+	maxConcurrency int
+}
+
+// concurrency returns the number of VPC lookups that may run in parallel
+func (f *Function) concurrency() int {
+	if f.maxConcurrency < 1 {
+		return 1
+	}
+	return f.maxConcurrency
 }